@@ -0,0 +1,83 @@
+package hocon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+const classpathScheme = "classpath:"
+
+// ClasspathRoot is a root of the classpath searched for the resources of the classpath(...) includes
+type ClasspathRoot struct {
+	Name string // describes the root in the names of the resources found in it, e.g. the directory or the module
+	FS   fs.FS
+}
+
+// ClasspathDir function returns the ClasspathRoot of the given directory
+func ClasspathDir(dir string) ClasspathRoot {
+	return ClasspathRoot{Name: dir, FS: os.DirFS(dir)}
+}
+
+// ClasspathIncluder is an Includer that searches the resources of the classpath(...) includes in all the roots
+// and merges every match with the matches of the earlier roots taking precedence, so each library can contribute
+// its own reference.conf, the bare includes of the found resources are searched in the roots relative to the resource
+// and the other includes are opened with the Fallback
+type ClasspathIncluder struct {
+	// Roots are searched for the resources in order
+	Roots []ClasspathRoot
+	// Fallback opens the includes that are not searched in the roots, FileIncluder is used if it is nil
+	Fallback Includer
+}
+
+// Include method opens the resources of the include found in the roots, the names of the resources are
+// "classpath:<root name>!/<resource>", e.g. "classpath:vendor/db!/reference.conf"
+func (c *ClasspathIncluder) Include(include Include) ([]IncludeSource, error) {
+	var resource string
+
+	switch {
+	case include.Kind == ClasspathInclude:
+		resource = strings.TrimPrefix(path.Clean(include.Location), "/")
+	case include.Kind == BareInclude && strings.HasPrefix(include.Parent, classpathScheme):
+		_, parent, _ := strings.Cut(include.Parent, "!/")
+		resource = path.Join(path.Dir(parent), include.Location)
+	default:
+		return c.fallback().Include(include)
+	}
+
+	var sources []IncludeSource
+
+	for _, root := range slices.Backward(c.Roots) { // the later sources override the earlier ones when merged
+		file, err := root.FS.Open(resource)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			closeSources(sources)
+
+			return nil, err
+		}
+
+		name := classpathScheme + root.Name + "!/" + resource
+		sources = append(sources, IncludeSource{Name: name, Reader: file})
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%s is not found in the classpath: %w", resource, fs.ErrNotExist)
+	}
+
+	return sources, nil
+}
+
+func (c *ClasspathIncluder) fallback() Includer {
+	if c.Fallback != nil {
+		return c.Fallback
+	}
+
+	return FileIncluder()
+}
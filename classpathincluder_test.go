@@ -0,0 +1,68 @@
+package hocon
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestClasspathIncluder(t *testing.T) {
+	db := fstest.MapFS{
+		"reference.conf":    {Data: []byte("db { host: localhost, port: 5432 }\ninclude \"db/pool.conf\"")},
+		"db/pool.conf":      {Data: []byte("pool.size: 10")},
+		"db/defaults.json":  {Data: []byte(`{"timeout": "5s"}`)},
+		"db/reference.conf": {Data: []byte(`include "defaults"`)},
+	}
+	app := fstest.MapFS{
+		"reference.conf": {Data: []byte("db.host: app\napp.name: app")},
+		"db/pool.conf":   {Data: []byte("pool.max: 20")},
+	}
+	includer := &ClasspathIncluder{Roots: []ClasspathRoot{{Name: "app", FS: app}, {Name: "db", FS: db}}}
+	opts := ParseOptions{Includer: includer}
+
+	t.Run("merge the resources of all the roots with the earlier roots taking precedence", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include classpath("reference.conf")`, opts)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"db":{"host":"app", "port":5432}, "pool":{"size":10, "max":20}, "app":{"name":"app"}}`)
+		origin, err := got.Origin("db.port")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "classpath:db!/reference.conf", Line: 1, Column: 23, IncludeChain: []string{""}})
+		origin, err = got.Origin("pool.max")
+		assertNoError(t, err)
+		expected := &Origin{Filename: "classpath:app!/db/pool.conf", Line: 1, Column: 6, IncludeChain: []string{"", "classpath:db!/reference.conf"}}
+		assertDeepEqual(t, origin, expected)
+	})
+
+	t.Run("search the bare includes of the resources relative to them and probe the extensions", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include classpath("/db/reference")`, opts)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"timeout":"5s"}`)
+	})
+
+	t.Run("open the other includes with the fallback", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "testdata/a.conf"`, opts)
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("a"), 1)
+	})
+
+	t.Run("ignore the optional resource that is not found", func(t *testing.T) {
+		got, err := ParseStringWithOptions("include classpath(\"missing.conf\")\na: 1", opts)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":1}`)
+	})
+
+	t.Run("return an error if the required resource is not found", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include required(classpath("missing.conf"))`, opts)
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not parse resource: missing.conf is not found in the classpath: file does not exist"))
+	})
+
+	t.Run("search the directories", func(t *testing.T) {
+		includer := &ClasspathIncluder{Roots: []ClasspathRoot{ClasspathDir("testdata/nested"), ClasspathDir("testdata")}}
+		got, err := ParseStringWithOptions(`include classpath("a.conf")`, ParseOptions{Includer: includer})
+		assertNoError(t, err)
+		origin, err := got.Origin("a")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "classpath:testdata!/a.conf", Line: 1, Column: 1, IncludeChain: []string{""}})
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -51,13 +53,13 @@ func (c *Config) GetRoot() Value {
 }
 
 // GetObject method finds the value at the given path and returns it as an Object, returns nil if the value is not found
-func (c *Config) GetObject(path string) (Object, error) {
+func (c *Config) GetObject(path string) (*Object, error) {
 	value := c.get(path)
 	if value == nil {
 		return nil, fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	val, ok := value.(Object)
+	val, ok := value.(*Object)
 	if !ok {
 		return nil, fmt.Errorf("config value at path: %s is not an object", path)
 	}
@@ -84,10 +86,20 @@ func (c *Config) GetConfigOrPanic(path string) *Config {
 	return value
 }
 
-// GetStringMap method finds the value at the given path and returns it as a map[string]Value
-// returns nil if the value is not found
+// GetStringMap method finds the value at the given path and returns a copy of its entries as a map[string]Value,
+// the changes of the map are not reflected in the config, returns nil if the value is not found
 func (c *Config) GetStringMap(path string) (map[string]Value, error) {
-	return c.GetObject(path)
+	object, err := c.GetObject(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m = make(map[string]Value, object.Len())
+	for k, v := range object.All() {
+		m[k] = v
+	}
+
+	return m, nil
 }
 
 func (c *Config) GetStringMapOrPanic(path string) map[string]Value {
@@ -107,13 +119,13 @@ func (c *Config) GetStringMapString(path string) (map[string]string, error) {
 		return nil, fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	object, ok := value.(Object)
+	object, ok := value.(*Object)
 	if !ok {
 		return nil, fmt.Errorf("config value at path: %s is not an object", path)
 	}
 
-	var m = make(map[string]string, len(object))
-	for k, v := range object {
+	var m = make(map[string]string, object.Len())
+	for k, v := range object.All() {
 		m[k] = v.String()
 	}
 
@@ -245,18 +257,12 @@ func (c *Config) GetInt(path string) (int, error) {
 		return 0, fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	switch val := value.(type) {
-	case Int:
-		return int(val), nil
-	case String:
-		i, err := strconv.Atoi(string(val))
-		if err != nil {
-			return 0, fmt.Errorf("cannot parse value: %s to int", path)
-		}
-		return i, nil
-	default:
+	i, ok := intValue(value)
+	if !ok {
 		return 0, fmt.Errorf("cannot parse value: %s to int", path)
 	}
+
+	return i, nil
 }
 
 func (c *Config) GetIntOrPanic(path string) int {
@@ -276,20 +282,12 @@ func (c *Config) GetFloat32(path string) (float32, error) {
 		return float32(0.0), fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	switch val := value.(type) {
-	case Float32:
-		return float32(val), nil
-	case Float64:
-		return float32(val), nil
-	case String:
-		floatValue, err := strconv.ParseFloat(string(val), 32)
-		if err != nil {
-			return float32(0.0), fmt.Errorf("cannot parse value: %s to float32", path)
-		}
-		return float32(floatValue), nil
-	default:
+	f, ok := float32Value(value)
+	if !ok {
 		return float32(0.0), fmt.Errorf("cannot parse value: %s to float32", path)
 	}
+
+	return f, nil
 }
 
 func (c *Config) GetFloat32OrPanic(path string) float32 {
@@ -309,21 +307,12 @@ func (c *Config) GetFloat64(path string) (float64, error) {
 		return 0.0, fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	switch val := value.(type) {
-	case Float64:
-		return float64(val), nil
-	case Float32:
-		return float64(val), nil
-	case String:
-		floatValue, err := strconv.ParseFloat(string(val), 64)
-		if err != nil {
-			return 0.0, fmt.Errorf("cannot parse value: %s to float64", path)
-		}
-		return floatValue, nil
-	default:
-
+	f, ok := float64Value(value)
+	if !ok {
 		return 0.0, fmt.Errorf("cannot parse value: %s to float64", path)
 	}
+
+	return f, nil
 }
 
 func (c *Config) GetFloat64OrPanic(path string) float64 {
@@ -343,21 +332,12 @@ func (c *Config) GetBoolean(path string) (bool, error) {
 		return false, fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	switch val := value.(type) {
-	case Boolean:
-		return bool(val), nil
-	case String:
-		switch val {
-		case "true", "yes", "on":
-			return true, nil
-		case "false", "no", "off":
-			return false, nil
-		default:
-			return false, fmt.Errorf("cannot parse value: %s to boolean", path)
-		}
-	default:
+	b, ok := booleanValue(value)
+	if !ok {
 		return false, fmt.Errorf("cannot parse value: %s to boolean", path)
 	}
+
+	return b, nil
 }
 
 func (c *Config) GetBooleanOrPanic(path string) bool {
@@ -377,12 +357,12 @@ func (c *Config) GetDuration(path string) (time.Duration, error) {
 		return 0, fmt.Errorf("config value not found at path: %s", path)
 	}
 
-	dur, ok := value.(Duration)
+	dur, ok := durationValue(value)
 	if !ok {
 		return 0, fmt.Errorf("cannot parse value: %s to Duration", path)
 	}
 
-	return time.Duration(dur), nil
+	return dur, nil
 }
 
 func (c *Config) GetDurationOrPanic(path string) time.Duration {
@@ -394,6 +374,87 @@ func (c *Config) GetDurationOrPanic(path string) time.Duration {
 	return value
 }
 
+// intValue converts the given value to an int, the value should be an Int or a String that can be converted to int
+func intValue(value Value) (int, bool) {
+	switch val := value.(type) {
+	case Int:
+		return int(val), true
+	case String:
+		i, err := strconv.Atoi(string(val))
+		if err != nil {
+			return 0, false
+		}
+		return i, true
+	default:
+		return 0, false
+	}
+}
+
+// float32Value converts the given value to a float32, the value should be a Float32, a Float64 or a String
+// that can be converted to float32
+func float32Value(value Value) (float32, bool) {
+	switch val := value.(type) {
+	case Float32:
+		return float32(val), true
+	case Float64:
+		return float32(val), true
+	case String:
+		floatValue, err := strconv.ParseFloat(string(val), 32)
+		if err != nil {
+			return float32(0.0), false
+		}
+		return float32(floatValue), true
+	default:
+		return float32(0.0), false
+	}
+}
+
+// float64Value converts the given value to a float64, the value should be a Float64, a Float32 or a String
+// that can be converted to float64
+func float64Value(value Value) (float64, bool) {
+	switch val := value.(type) {
+	case Float64:
+		return float64(val), true
+	case Float32:
+		return float64(val), true
+	case String:
+		floatValue, err := strconv.ParseFloat(string(val), 64)
+		if err != nil {
+			return 0.0, false
+		}
+		return floatValue, true
+	default:
+		return 0.0, false
+	}
+}
+
+// booleanValue converts the given value to a bool, the value should be a Boolean or one of the boolean strings
+// (true, yes, on, false, no, off)
+func booleanValue(value Value) (bool, bool) {
+	switch val := value.(type) {
+	case Boolean:
+		return bool(val), true
+	case String:
+		b, err := newBooleanFromString(string(val))
+		if err != nil {
+			return false, false
+		}
+		return bool(b), true
+	default:
+		return false, false
+	}
+}
+
+// durationValue converts the given value to a time.Duration, the value should be a Duration
+func durationValue(value Value) (time.Duration, bool) {
+	dur, ok := value.(Duration)
+	if !ok {
+		return 0, false
+	}
+
+	return time.Duration(dur), true
+}
+
 func (c *Config) Has(path string) bool {
 	return c.get(path) != nil
 }
@@ -405,18 +466,19 @@ func (c *Config) get(path string) Value {
 		return nil
 	}
 
-	return c.root.(Object).find(path)
+	return c.root.(*Object).find(path)
 }
 
 // WithFallback method returns a new *Config (or the current config, if the given fallback doesn't get used)
 // 1. merges the values of the current and fallback *Configs, if the root of both of them are of type Object
-// for the same keys current values overrides the fallback values
+// for the same keys current values overrides the fallback values, the keys of the current config come first
+// and the keys that exist only in the fallback config are added after them
 // 2. if any of the *Configs has non-object root then returns the current *Config ignoring the fallback parameter
 func (c *Config) WithFallback(fallback *Config) *Config {
-	if current, ok := c.root.(Object); ok {
-		if fallbackObject, ok := fallback.root.(Object); ok {
-			resultConfig := fallbackObject.copy()
-			mergeObjects(resultConfig, current)
+	if current, ok := c.root.(*Object); ok {
+		if fallbackObject, ok := fallback.root.(*Object); ok {
+			resultConfig := current.copy()
+			mergeFallback(resultConfig, fallbackObject)
 
 			return resultConfig.ToConfig()
 		}
@@ -430,6 +492,7 @@ type Value interface {
 	Type() Type
 	String() string
 	Json() string
+	Render(opts RenderOptions) string
 	isConcatenable() bool
 }
 
@@ -449,10 +512,12 @@ func (s String) Json() string {
 
 func (s String) isConcatenable() bool { return true }
 
-// valueWithAlternative represents a value with Substitution which might override the original value
+// valueWithAlternative represents a value redefined with an alternative containing substitutions, the alternative
+// overrides the original value unless it turns out to be undefined and the substitutions of the alternative that refer
+// to the key itself are resolved to the original value
 type valueWithAlternative struct {
 	value       Value
-	alternative *Substitution
+	alternative Value
 }
 
 func (s *valueWithAlternative) Type() Type { return valueWithAlternativeType }
@@ -467,40 +532,139 @@ func (s *valueWithAlternative) Json() string {
 
 func (s *valueWithAlternative) isConcatenable() bool { return false }
 
-// Object represents an object node in the configuration tree
-type Object map[string]Value
+// Object represents an object node in the configuration tree,
+// it remembers the order in which the keys were first defined
+type Object struct {
+	keys    []string
+	items   map[string]Value
+	origins map[string]*Origin
+}
+
+// NewObject function creates an empty Object
+func NewObject() *Object {
+	return &Object{items: make(map[string]Value)}
+}
 
 // Type Object
-func (o Object) Type() Type           { return ObjectType }
-func (o Object) isConcatenable() bool { return false }
+func (o *Object) Type() Type           { return ObjectType }
+func (o *Object) isConcatenable() bool { return false }
+
+// Get method returns the value of the given key and reports whether the key exists in the Object
+func (o *Object) Get(key string) (Value, bool) {
+	if o == nil {
+		return nil, false
+	}
+
+	value, ok := o.items[key]
+
+	return value, ok
+}
+
+// Set method sets the value of the given key, the key keeps its position if it already exists
+// otherwise it is added after the existing keys
+func (o *Object) Set(key string, value Value) {
+	if o.items == nil {
+		o.items = make(map[string]Value)
+	}
+
+	if _, ok := o.items[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.items[key] = value
+}
+
+// Delete method removes the given key from the Object
+func (o *Object) Delete(key string) {
+	if _, ok := o.items[key]; !ok {
+		return
+	}
+
+	delete(o.items, key)
+	delete(o.origins, key)
+	o.keys = slices.DeleteFunc(o.keys, func(k string) bool { return k == key })
+}
+
+// Origin method returns where the value of the given key was defined, reports false if the key does not exist
+// or the value was not created by the parser
+func (o *Object) Origin(key string) (*Origin, bool) {
+	if o == nil {
+		return nil, false
+	}
+
+	origin, ok := o.origins[key]
+
+	return origin, ok
+}
+
+func (o *Object) setOrigin(key string, origin *Origin) {
+	if origin == nil {
+		return
+	}
+
+	if o.origins == nil {
+		o.origins = make(map[string]*Origin)
+	}
+
+	o.origins[key] = origin
+}
+
+// Len method returns the number of the keys in the Object
+func (o *Object) Len() int {
+	if o == nil {
+		return 0
+	}
+
+	return len(o.keys)
+}
+
+// Keys method returns the keys of the Object in the order they were first defined
+func (o *Object) Keys() []string {
+	if o == nil {
+		return nil
+	}
+
+	return append([]string(nil), o.keys...)
+}
+
+// All method returns an iterator over the keys and values of the Object in the order the keys were first defined
+func (o *Object) All() iter.Seq2[string, Value] {
+	return func(yield func(string, Value) bool) {
+		if o == nil {
+			return
+		}
+
+		for _, key := range o.keys {
+			if !yield(key, o.items[key]) {
+				return
+			}
+		}
+	}
+}
 
 // String method returns the string representation of the Object
-func (o Object) String() string {
+func (o *Object) String() string {
 	return o.Json()
 }
 
-func (o Object) Json() string {
+func (o *Object) Json() string {
 	var builder strings.Builder
 
-	itemsSize := len(o)
-	i := 1
-
 	builder.WriteString(objectStartToken)
 
-	for key, value := range o {
+	for i, key := range o.Keys() {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+
 		builder.WriteString(jsonMarshal(key))
 		builder.WriteString(colonToken)
 
-		if value != nil {
+		if value := o.items[key]; value != nil {
 			builder.WriteString(value.Json())
 		} else {
 			builder.WriteString(string(null))
 		}
-
-		if i < itemsSize {
-			builder.WriteString(", ")
-		}
-		i++
 	}
 
 	builder.WriteString(objectEndToken)
@@ -509,11 +673,11 @@ func (o Object) Json() string {
 }
 
 // ToConfig method converts object to *Config
-func (o Object) ToConfig() *Config {
+func (o *Object) ToConfig() *Config {
 	return &Config{o}
 }
 
-func (o Object) find(path string) Value {
+func (o *Object) find(path string) Value {
 	keys := strings.Split(path, dotToken)
 	size := len(keys)
 	lastKey := keys[size-1]
@@ -521,32 +685,58 @@ func (o Object) find(path string) Value {
 	object := o
 
 	for _, key := range keysWithoutLast {
-		value, ok := object[key]
+		value, ok := object.Get(key)
 		if !ok {
 			return nil
 		}
 
-		object = value.(Object)
+		if object, ok = value.(*Object); !ok {
+			return nil
+		}
 	}
 
-	return object[lastKey]
+	value, _ := object.Get(lastKey)
+
+	return value
 }
 
-func (o Object) copy() Object {
-	result := Object{}
+func (o *Object) copy() *Object {
+	result := NewObject()
 
-	for k, v := range o {
-		subObject, ok := v.(Object)
-		if ok {
-			result[k] = subObject.copy()
-		} else {
-			result[k] = v
-		}
+	for k, v := range o.All() {
+		result.Set(k, copyValue(v))
+		result.setOrigin(k, o.origins[k])
 	}
 
 	return result
 }
 
+// copyValue returns a deep copy of the objects, arrays and concatenations, so that resolving the substitutions
+// of the copy does not modify the given value
+func copyValue(value Value) Value {
+	switch v := value.(type) {
+	case *Object:
+		return v.copy()
+	case Array:
+		if v == nil {
+			return v
+		}
+
+		result := make(Array, len(v))
+		for i, element := range v {
+			result[i] = copyValue(element)
+		}
+
+		return result
+	case concatenation:
+		return concatenation(copyValue(Array(v)).(Array))
+	case *valueWithAlternative:
+		return &valueWithAlternative{value: copyValue(v.value), alternative: copyValue(v.alternative)}
+	}
+
+	return value
+}
+
 // Array represents an array node in the configuration tree
 type Array []Value
 
@@ -630,6 +820,8 @@ func (b Boolean) isConcatenable() bool { return true }
 type Substitution struct {
 	path     string
 	optional bool
+	origin   *Origin
+	prefix   string // the path that an included source is included at, ${prefix.path} is tried before ${path}
 }
 
 // Type Substitution
@@ -8,7 +8,7 @@ import (
 )
 
 func TestGetRoot(t *testing.T) {
-	root := Object{"a": Object{"b": String("c")}, "d": Array{}}
+	root := objectOf("a", objectOf("b", String("c")), "d", Array{})
 	config := &Config{root}
 
 	t.Run("get root value", func(t *testing.T) {
@@ -18,11 +18,11 @@ func TestGetRoot(t *testing.T) {
 }
 
 func TestGetObject(t *testing.T) {
-	config := &Config{Object{"a": Object{"b": String("c")}, "d": Array{}}}
+	config := &Config{objectOf("a", objectOf("b", String("c")), "d", Array{})}
 
 	t.Run("get object", func(t *testing.T) {
 		got, _ := config.GetObject("a")
-		assertDeepEqual(t, got, Object{"b": String("c")})
+		assertDeepEqual(t, got, objectOf("b", String("c")))
 	})
 
 	t.Run("return nil for a non-existing object", func(t *testing.T) {
@@ -37,8 +37,8 @@ func TestGetObject(t *testing.T) {
 }
 
 func TestGetConfig(t *testing.T) {
-	nestedConfig := &Config{Object{"b": String("c"), "d": Array{}}}
-	config := &Config{Object{"a": nestedConfig.root}}
+	nestedConfig := &Config{objectOf("b", String("c"), "d", Array{})}
+	config := &Config{objectOf("a", nestedConfig.root)}
 
 	t.Run("get nested config", func(t *testing.T) {
 		got, _ := config.GetConfig("a")
@@ -53,14 +53,14 @@ func TestGetConfig(t *testing.T) {
 }
 
 func TestGetStringMap(t *testing.T) {
-	object := Object{"b": Int(1)}
-	config := &Config{Object{"a": object}}
+	object := objectOf("b", Int(1))
+	config := &Config{objectOf("a", object)}
 	got, _ := config.GetObject("a")
 	assertDeepEqual(t, got, object)
 }
 
 func TestGetStringMapString(t *testing.T) {
-	config := &Config{Object{"a": Object{"b": String("c"), "e": Int(1)}, "d": Array{}}}
+	config := &Config{objectOf("a", objectOf("b", String("c"), "e", Int(1)), "d", Array{})}
 
 	t.Run("get object as map[string]string", func(t *testing.T) {
 		got, _ := config.GetStringMapString("a")
@@ -75,7 +75,7 @@ func TestGetStringMapString(t *testing.T) {
 }
 
 func TestGetArray(t *testing.T) {
-	config := &Config{Object{"a": Array{Int(1), Int(2)}, "b": Object{"c": String("d")}}}
+	config := &Config{objectOf("a", Array{Int(1), Int(2)}, "b", objectOf("c", String("d")))}
 
 	t.Run("get array", func(t *testing.T) {
 		got, _ := config.GetArray("a")
@@ -94,7 +94,7 @@ func TestGetArray(t *testing.T) {
 }
 
 func TestGetIntSlice(t *testing.T) {
-	config := &Config{Object{"a": Array{Int(1), Int(2)}, "b": Array{String("c"), Int(1)}}}
+	config := &Config{objectOf("a", Array{Int(1), Int(2)}, "b", Array{String("c"), Int(1)})}
 
 	t.Run("get array as int slice", func(t *testing.T) {
 		got, _ := config.GetIntSlice("a")
@@ -115,7 +115,7 @@ func TestGetIntSlice(t *testing.T) {
 }
 
 func TestGetStringSlice(t *testing.T) {
-	config := &Config{Object{"a": Array{String("a"), String("b")}, "b": Array{Int(1), String("c")}}}
+	config := &Config{objectOf("a", Array{String("a"), String("b")}, "b", Array{Int(1), String("c")})}
 
 	t.Run("get array as string slice", func(t *testing.T) {
 		got, _ := config.GetStringSlice("a")
@@ -135,7 +135,7 @@ func TestGetStringSlice(t *testing.T) {
 }
 
 func TestGetString(t *testing.T) {
-	config := &Config{Object{"a": String("b"), "c": Int(2)}}
+	config := &Config{objectOf("a", String("b"), "c", Int(2))}
 
 	t.Run("get string", func(t *testing.T) {
 		got, _ := config.GetString("a")
@@ -155,7 +155,7 @@ func TestGetString(t *testing.T) {
 }
 
 func TestGetStringOrPanic(t *testing.T) {
-	config := &Config{Object{"a": String("b"), "c": Int(2)}}
+	config := &Config{objectOf("a", String("b"), "c", Int(2))}
 
 	t.Run("get string", func(t *testing.T) {
 		got := config.GetStringOrPanic("a")
@@ -170,7 +170,7 @@ func TestGetStringOrPanic(t *testing.T) {
 }
 
 func TestGetInt(t *testing.T) {
-	config := &Config{Object{"a": String("aa"), "b": String("3"), "c": Int(2), "d": Array{Int(5)}}}
+	config := &Config{objectOf("a", String("aa"), "b", String("3"), "c", Int(2), "d", Array{Int(5)})}
 
 	t.Run("get int", func(t *testing.T) {
 		got, _ := config.GetInt("c")
@@ -202,7 +202,7 @@ func TestGetInt(t *testing.T) {
 }
 
 func TestGetFloat32(t *testing.T) {
-	config := &Config{Object{"a": String("aa"), "b": String("3.2"), "c": Float32(2.4), "d": Array{Int(5)}, "e": Float64(2.5)}}
+	config := &Config{objectOf("a", String("aa"), "b", String("3.2"), "c", Float32(2.4), "d", Array{Int(5)}, "e", Float64(2.5))}
 
 	t.Run("get float32", func(t *testing.T) {
 		got, _ := config.GetFloat32("c")
@@ -239,7 +239,7 @@ func TestGetFloat32(t *testing.T) {
 }
 
 func TestGetFloat64(t *testing.T) {
-	config := &Config{Object{"a": String("aa"), "b": String("3.2"), "c": Float32(2.4), "d": Array{Int(5)}, "e": Float64(2.5)}}
+	config := &Config{objectOf("a", String("aa"), "b", String("3.2"), "c", Float32(2.4), "d", Array{Int(5)}, "e", Float64(2.5))}
 
 	t.Run("get float64", func(t *testing.T) {
 		got, _ := config.GetFloat64("e")
@@ -276,18 +276,18 @@ func TestGetFloat64(t *testing.T) {
 }
 
 func TestGetBoolean(t *testing.T) {
-	config := &Config{Object{
-		"a": Boolean(true),
-		"b": Boolean(false),
-		"c": String("true"),
-		"d": String("yes"),
-		"e": String("on"),
-		"f": String("false"),
-		"g": String("no"),
-		"h": String("off"),
-		"i": String("aa"),
-		"j": Array{Int(5)},
-	}}
+	config := &Config{objectOf(
+		"a", Boolean(true),
+		"b", Boolean(false),
+		"c", String("true"),
+		"d", String("yes"),
+		"e", String("on"),
+		"f", String("false"),
+		"g", String("no"),
+		"h", String("off"),
+		"i", String("aa"),
+		"j", Array{Int(5)},
+	)}
 
 	t.Run("return error for a non-existing boolean", func(t *testing.T) {
 		got, err := config.GetBoolean("z")
@@ -330,7 +330,7 @@ func TestGetBoolean(t *testing.T) {
 }
 
 func TestGetDuration(t *testing.T) {
-	config := &Config{Object{"a": Duration(5 * time.Second), "b": String("bb")}}
+	config := &Config{objectOf("a", Duration(5*time.Second), "b", String("bb"))}
 
 	t.Run("get Duration at the given path", func(t *testing.T) {
 		got, _ := config.GetDuration("a")
@@ -351,16 +351,25 @@ func TestGetDuration(t *testing.T) {
 }
 
 func TestWithFallback(t *testing.T) {
-	config1 := &Config{Object{"a": String("aa"), "b": String("bb")}}
-	config2 := &Config{Object{"a": String("aaa"), "c": String("cc")}}
+	config1 := &Config{objectOf("a", String("aa"), "b", String("bb"))}
+	config2 := &Config{objectOf("a", String("aaa"), "c", String("cc"))}
 	config3 := &Config{Array{Int(1), Int(2)}}
 
 	t.Run("merge the given fallback config with the current config if the root of both of them are of type Object (for the same keys current config should override the fallback)", func(t *testing.T) {
-		expected := &Config{Object{"a": String("aa"), "b": String("bb"), "c": String("cc")}}
+		expected := &Config{objectOf("a", String("aa"), "b", String("bb"), "c", String("cc"))}
 		got := config1.WithFallback(config2)
 		assertDeepEqual(t, got, expected)
 	})
 
+	t.Run("merge the nested objects and keep the keys of the current config first", func(t *testing.T) {
+		current := &Config{objectOf("b", objectOf("y", Int(1)), "a", Int(1))}
+		fallback := &Config{objectOf("c", Int(3), "b", objectOf("x", Int(2), "y", Int(3)))}
+		expected := &Config{objectOf("b", objectOf("y", Int(1), "x", Int(2)), "a", Int(1), "c", Int(3))}
+		got := current.WithFallback(fallback)
+		assertDeepEqual(t, got, expected)
+		assertDeepEqual(t, fallback, &Config{objectOf("c", Int(3), "b", objectOf("x", Int(2), "y", Int(3)))})
+	})
+
 	t.Run("return the current config if the root of the given fallback config is not an Object", func(t *testing.T) {
 		got := config1.WithFallback(config3)
 		assertDeepEqual(t, got, config1)
@@ -374,25 +383,25 @@ func TestWithFallback(t *testing.T) {
 
 func TestFind(t *testing.T) {
 	t.Run("return nil if path does not contain any dot and there is no value with the given path", func(t *testing.T) {
-		object := Object{"a": Int(1)}
+		object := objectOf("a", Int(1))
 		got := object.find("b")
 		assertNil(t, got)
 	})
 
 	t.Run("find the value with the path that does not contain any dot", func(t *testing.T) {
-		object := Object{"a": Int(1)}
+		object := objectOf("a", Int(1))
 		got := object.find("a")
 		assertEquals(t, got, Int(1))
 	})
 
 	t.Run("return nil if path contains dot and there is no value with the sub-path", func(t *testing.T) {
-		object := Object{"a": Object{"b": Int(1)}}
+		object := objectOf("a", objectOf("b", Int(1)))
 		got := object.find("c.b")
 		assertNil(t, got)
 	})
 
 	t.Run("find the value with the path that contains dots", func(t *testing.T) {
-		object := Object{"a": Object{"b": Int(1)}}
+		object := objectOf("a", objectOf("b", Int(1)))
 		got := object.find("a.b")
 		assertEquals(t, got, Int(1))
 	})
@@ -400,37 +409,78 @@ func TestFind(t *testing.T) {
 
 func TestObject_String(t *testing.T) {
 	t.Run("return the string of an empty object", func(t *testing.T) {
-		got := Object{}.String()
+		got := objectOf().String()
 		assertEquals(t, got, "{}")
 	})
 
 	t.Run("return the string of an object that contains a empty string", func(t *testing.T) {
-		got := Object{"a": String("")}.String()
+		got := objectOf("a", String("")).String()
 		assertEquals(t, got, `{"a":""}`)
 	})
 
 	t.Run("return the string of an object that contains a single element", func(t *testing.T) {
-		got := Object{"a": Int(1)}.String()
+		got := objectOf("a", Int(1)).String()
 		assertEquals(t, got, `{"a":1}`)
 	})
 
 	t.Run("return the string of an object that contains multiple elements", func(t *testing.T) {
-		got := Object{"a": Int(1), "b": Int(2)}.String()
-		if got != `{"a":1, "b":2}` && got != `{"b":2, "a":1}` {
-			fail(t, got, `{"a":1, "b":2}`)
-		}
+		got := objectOf("b", Int(2), "a", Int(1)).String()
+		assertEquals(t, got, `{"b":2, "a":1}`)
 	})
 
 	t.Run("return the string of an object that contains a single element with the forbidden characters", func(t *testing.T) {
-		got := Object{"a": String("!@#$%^&*()_+{}[];:',./<>?\"\\")}.String()
+		got := objectOf("a", String("!@#$%^&*()_+{}[];:',./<>?\"\\")).String()
 		assertEquals(t, got, `{"a":"!@#$%^&*()_+{}[];:',./<>?\"\\"}`)
 	})
 
 	t.Run("return the string of an object that contains multiple elements with the forbidden characters", func(t *testing.T) {
-		got := Object{"a": String("!@#$%^&*()_+{}[];:',./<>?\"\\"), "b": Int(2)}.String()
-		if got != `{"a":"!@#$%^&*()_+{}[];:',./<>?\"\\", "b":2}` && got != `{"b":2, "a":"!@#$%^&*()_+{}[];:',./<>?\"\\"}` {
-			fail(t, got, `{a:"!@#$%^&*()_+{}[];:',./<>?\"\\", "b":2}`)
+		got := objectOf("a", String("!@#$%^&*()_+{}[];:',./<>?\"\\"), "b", Int(2)).String()
+		assertEquals(t, got, `{"a":"!@#$%^&*()_+{}[];:',./<>?\"\\", "b":2}`)
+	})
+}
+
+func TestObject(t *testing.T) {
+	t.Run("keep the insertion order of the keys", func(t *testing.T) {
+		object := NewObject()
+		object.Set("c", Int(1))
+		object.Set("a", Int(2))
+		object.Set("b", Int(3))
+		object.Set("a", Int(4))
+		assertDeepEqual(t, object.Keys(), []string{"c", "a", "b"})
+		assertEquals(t, object.Len(), 3)
+		value, ok := object.Get("a")
+		assertEquals(t, ok, true)
+		assertDeepEqual(t, value, Int(4))
+	})
+
+	t.Run("remove the deleted key from the order", func(t *testing.T) {
+		object := objectOf("a", Int(1), "b", Int(2), "c", Int(3))
+		object.Delete("b")
+		object.Delete("d")
+		assertDeepEqual(t, object.Keys(), []string{"a", "c"})
+		_, ok := object.Get("b")
+		assertEquals(t, ok, false)
+	})
+
+	t.Run("iterate over the keys and values in insertion order", func(t *testing.T) {
+		object := objectOf("b", Int(1), "a", Int(2))
+		var keys []string
+		var values []Value
+		for key, value := range object.All() {
+			keys = append(keys, key)
+			values = append(values, value)
 		}
+		assertDeepEqual(t, keys, []string{"b", "a"})
+		assertDeepEqual(t, values, []Value{Int(1), Int(2)})
+	})
+
+	t.Run("keep the order of the keys in the parsed config", func(t *testing.T) {
+		config, err := ParseString(`z: 1, a { y: 2, b: 3 }, m: 4, a.c: 5, z: 6`)
+		assertNoError(t, err)
+		assertDeepEqual(t, config.GetRoot().(*Object).Keys(), []string{"z", "a", "m"})
+		object, err := config.GetObject("a")
+		assertNoError(t, err)
+		assertDeepEqual(t, object.Keys(), []string{"y", "b", "c"})
 	})
 }
 
@@ -474,13 +524,13 @@ func TestGet(t *testing.T) {
 	})
 
 	t.Run("find the value if the root of config is an object and a value exist with the given path", func(t *testing.T) {
-		config := &Config{Object{"a": Int(1)}}
+		config := &Config{objectOf("a", Int(1))}
 		got := config.get("a")
 		assertEquals(t, got, Int(1))
 	})
 
 	t.Run("return nil if the root of config is an object but value with the given path does not exist", func(t *testing.T) {
-		config := &Config{Object{"a": Int(1)}}
+		config := &Config{objectOf("a", Int(1))}
 		got := config.get("b")
 		assertNil(t, got)
 	})
@@ -537,7 +587,7 @@ func TestValueWithAlternative_String(t *testing.T) {
 }
 
 func TestToConfig(t *testing.T) {
-	object := Object{"a": Int(1)}
+	object := objectOf("a", Int(1))
 	got := object.ToConfig()
 	assertDeepEqual(t, got.root, object)
 }
@@ -550,7 +600,7 @@ func TestContainsObject(t *testing.T) {
 	})
 
 	t.Run("return true if the concatenation contains an Object", func(t *testing.T) {
-		concatenation := concatenation{Object{"a": String("aa")}, String("b")}
+		concatenation := concatenation{objectOf("a", String("aa")), String("b")}
 		got := concatenation.containsObject()
 		assertEquals(t, got, true)
 	})
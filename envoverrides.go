@@ -0,0 +1,208 @@
+package hocon
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const envOverridePrefix = "CONFIG_FORCE_"
+
+// EnvOverrides function creates a Config of the environment variables with the CONFIG_FORCE_ prefix of the given
+// environment (in the "key=value" format of os.Environ) to put in front of the fallback chain, the rest of the name
+// is the path of the value where "_" separates the keys, "__" stands for "-" and "___" for "_" and the numeric keys
+// are the indexes of an array, e.g. CONFIG_FORCE_db_max__pool___size=10 sets db.max-pool_size to 10 and
+// CONFIG_FORCE_hosts_0=a replaces the whole hosts array of the fallback chain with ["a"], the indexes of an array
+// must be contiguous from 0, the values are parsed as hocon values (the invalid ones are kept as strings)
+// and the substitutions in them are resolved with the fallback chain
+func EnvOverrides(environ []string) (*Config, error) {
+	root := NewObject()
+
+	environ = slices.Clone(environ)
+	slices.Sort(environ)
+
+	for _, variable := range environ {
+		name, input, _ := strings.Cut(variable, equalsToken)
+		if !strings.HasPrefix(name, envOverridePrefix) {
+			continue
+		}
+
+		path, err := envOverridePath(strings.TrimPrefix(name, envOverridePrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid env override: %s, %w", name, err)
+		}
+
+		value, err := parseValue(input)
+		if err != nil {
+			value = String(input)
+		}
+
+		if err := setEnvOverride(root, path, value); err != nil {
+			return nil, fmt.Errorf("invalid env override: %s, %w", name, err)
+		}
+	}
+
+	converted, err := convertArrayIndexes(root)
+	if err != nil {
+		return nil, fmt.Errorf("invalid env override: %w", err)
+	}
+
+	return converted.(*Object).ToConfig(), nil
+}
+
+// envOverrides returns the overrides of the environment of the options if they are enabled
+func (o LoadOptions) envOverrides() (*Config, error) {
+	if !o.EnvOverrides || o.Parse.DisableEnv {
+		return NewObject().ToConfig(), nil
+	}
+
+	return EnvOverrides(o.environ())
+}
+
+// environ returns the environment variables that the env overrides are read from: the Environ of the options,
+// the variables of the EnvMap of the Parse options or the environment of the process if the Env is not set,
+// the other Env implementations cannot list their variables, so they provide no env overrides
+func (o LoadOptions) environ() []string {
+	if o.Environ != nil {
+		return o.Environ
+	}
+
+	switch env := o.Parse.Env.(type) {
+	case nil:
+		return os.Environ()
+	case EnvMap:
+		environ := make([]string, 0, len(env))
+		for key, value := range env {
+			environ = append(environ, key+equalsToken+value)
+		}
+
+		return environ
+	}
+
+	return nil
+}
+
+// envOverridePath returns the path of the given name without the prefix
+func envOverridePath(name string) ([]string, error) {
+	var (
+		path []string
+		key  strings.Builder
+	)
+
+	for i := 0; i < len(name); {
+		if name[i] != '_' {
+			key.WriteByte(name[i])
+			i++
+
+			continue
+		}
+
+		underscores := len(name[i:]) - len(strings.TrimLeft(name[i:], "_"))
+
+		switch underscores {
+		case 1:
+			path = append(path, key.String())
+			key.Reset()
+		case 2:
+			key.WriteByte('-')
+		case 3:
+			key.WriteByte('_')
+		default:
+			return nil, fmt.Errorf("%d adjacent underscores cannot be mapped to a path", underscores)
+		}
+
+		i += underscores
+	}
+
+	path = append(path, key.String())
+
+	if slices.Contains(path, "") {
+		return nil, fmt.Errorf("the path contains an empty key")
+	}
+
+	return path, nil
+}
+
+// setEnvOverride sets the value at the given path creating the objects of the path
+func setEnvOverride(object *Object, path []string, value Value) error {
+	for i, key := range path[:len(path)-1] {
+		existing, ok := object.Get(key)
+		if !ok {
+			child := NewObject()
+			object.Set(key, child)
+			object = child
+
+			continue
+		}
+
+		child, ok := existing.(*Object)
+		if !ok {
+			return fmt.Errorf("%s is set to both a value and an object", strings.Join(path[:i+1], dotToken))
+		}
+
+		object = child
+	}
+
+	key := path[len(path)-1]
+	if _, ok := object.Get(key); ok {
+		return fmt.Errorf("%s is set to both a value and an object", strings.Join(path, dotToken))
+	}
+
+	object.Set(key, value)
+
+	return nil
+}
+
+// convertArrayIndexes replaces the objects with the numeric keys with the arrays of their values
+func convertArrayIndexes(value Value) (Value, error) {
+	object, ok := value.(*Object)
+	if !ok {
+		return value, nil
+	}
+
+	indexes := 0
+
+	for key, value := range object.All() {
+		converted, err := convertArrayIndexes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		object.Set(key, converted)
+
+		if _, err := strconv.Atoi(key); err == nil {
+			indexes++
+		}
+	}
+
+	if indexes == 0 {
+		return object, nil
+	}
+
+	if indexes != object.Len() {
+		return nil, fmt.Errorf("array indexes are mixed with the keys: %s", strings.Join(object.Keys(), ", "))
+	}
+
+	array := make(Array, object.Len())
+
+	for key, value := range object.All() {
+		index, _ := strconv.Atoi(key)
+		if strconv.Itoa(index) != key {
+			return nil, fmt.Errorf("array index %s is not a canonical number", key)
+		}
+
+		if index < 0 || index >= len(array) {
+			return nil, fmt.Errorf("array index %s is out of the range of the %d elements", key, len(array))
+		}
+
+		if array[index] != nil {
+			return nil, fmt.Errorf("array index %s is set more than once", key)
+		}
+
+		array[index] = value
+	}
+
+	return array, nil
+}
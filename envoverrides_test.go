@@ -0,0 +1,133 @@
+package hocon
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEnvOverrides(t *testing.T) {
+	t.Run("map the names of the prefixed variables to the paths", func(t *testing.T) {
+		environ := []string{
+			"CONFIG_FORCE_db_max__pool___size=10",
+			"CONFIG_FORCE_db_host=db.internal",
+			"HOME=/root",
+			"CONFIG_FORCE_name=",
+		}
+		got, err := EnvOverrides(environ)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"db":{"host":"db.internal", "max-pool_size":10}, "name":""}`)
+	})
+
+	t.Run("parse the values as hocon values", func(t *testing.T) {
+		environ := []string{
+			"CONFIG_FORCE_timeout=5 seconds",
+			"CONFIG_FORCE_hosts=[a, b]",
+			"CONFIG_FORCE_pool={ size: 10 }",
+			`CONFIG_FORCE_quoted="a b"`,
+			"CONFIG_FORCE_debug=true",
+		}
+		got, err := EnvOverrides(environ)
+		assertNoError(t, err)
+		assertEquals(t, got.GetDurationOrPanic("timeout").String(), "5s")
+		assertDeepEqual(t, got.GetStringSliceOrPanic("hosts"), []string{"a", "b"})
+		assertEquals(t, got.GetIntOrPanic("pool.size"), 10)
+		assertEquals(t, got.GetStringOrPanic("quoted"), "a b")
+		assertEquals(t, got.GetBooleanOrPanic("debug"), true)
+	})
+
+	t.Run("keep the values that are not valid hocon values as strings", func(t *testing.T) {
+		got, err := EnvOverrides([]string{"CONFIG_FORCE_url=http://localhost:8080/api"})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("url"), "http://localhost:8080/api")
+	})
+
+	t.Run("map the numeric keys to the indexes of an array", func(t *testing.T) {
+		environ := []string{"CONFIG_FORCE_hosts_1_port=2", "CONFIG_FORCE_hosts_0_port=1", "CONFIG_FORCE_tags_0=a"}
+		got, err := EnvOverrides(environ)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"hosts":[{"port":1},{"port":2}], "tags":["a"]}`)
+	})
+
+	t.Run("return an error if an index of an array is missing", func(t *testing.T) {
+		got, err := EnvOverrides([]string{"CONFIG_FORCE_hosts_0=a", "CONFIG_FORCE_hosts_2=c"})
+		assertNil(t, got)
+		assertError(t, err, errors.New("invalid env override: array index 2 is out of the range of the 2 elements"))
+	})
+
+	for _, tc := range []struct {
+		environ  []string
+		expected string
+	}{
+		{[]string{"CONFIG_FORCE_hosts_0=a", "CONFIG_FORCE_hosts_00=b"}, "invalid env override: array index 00 is not a canonical number"},
+		{[]string{"CONFIG_FORCE_hosts_01=a", "CONFIG_FORCE_hosts_0=b"}, "invalid env override: array index 01 is not a canonical number"},
+		{[]string{"CONFIG_FORCE_hosts_+0=a"}, "invalid env override: array index +0 is not a canonical number"},
+	} {
+		t.Run("return an error if an index of an array is not canonical "+tc.environ[0], func(t *testing.T) {
+			got, err := EnvOverrides(tc.environ)
+			assertNil(t, got)
+			assertError(t, err, errors.New(tc.expected))
+		})
+	}
+
+	t.Run("return an error if the array indexes are mixed with the keys", func(t *testing.T) {
+		got, err := EnvOverrides([]string{"CONFIG_FORCE_hosts_0=a", "CONFIG_FORCE_hosts_a=b"})
+		assertNil(t, got)
+		assertError(t, err, errors.New("invalid env override: array indexes are mixed with the keys: 0, a"))
+	})
+
+	t.Run("return an error if a path is set to both a value and an object", func(t *testing.T) {
+		got, err := EnvOverrides([]string{"CONFIG_FORCE_db_host=a", "CONFIG_FORCE_db=b"})
+		assertNil(t, got)
+		assertError(t, err, errors.New("invalid env override: CONFIG_FORCE_db_host, db is set to both a value and an object"))
+	})
+
+	for name, expected := range map[string]string{
+		"CONFIG_FORCE_a____b": "invalid env override: CONFIG_FORCE_a____b, 4 adjacent underscores cannot be mapped to a path",
+		"CONFIG_FORCE_a_":     "invalid env override: CONFIG_FORCE_a_, the path contains an empty key",
+		"CONFIG_FORCE_":       "invalid env override: CONFIG_FORCE_, the path contains an empty key",
+	} {
+		t.Run("return an error for the invalid name "+name, func(t *testing.T) {
+			got, err := EnvOverrides([]string{name + "=1"})
+			assertNil(t, got)
+			assertError(t, err, errors.New(expected))
+		})
+	}
+
+	t.Run("take precedence over the application config in Load and resolve the substitutions", func(t *testing.T) {
+		withReferences(t)
+		environ := []string{"CONFIG_FORCE_db_port=5433", `CONFIG_FORCE_db_url="postgres://"${db.host}":"${db.port}`}
+		app := fstest.MapFS{"application.conf": {Data: []byte("db { host: localhost, port: 5432 }")}}
+		override, err := ParseString("db.host: override")
+		assertNoError(t, err)
+
+		got, err := Load(LoadOptions{FS: app, EnvOverrides: true, Environ: environ, Overrides: []*Config{override}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5433)
+		assertEquals(t, got.GetStringOrPanic("db.url"), "postgres://override:5433")
+
+		got, err = Load(LoadOptions{FS: app, Environ: environ})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5432)
+	})
+
+	t.Run("read the env overrides of the EnvMap of the parse options in Load", func(t *testing.T) {
+		withReferences(t)
+		app := fstest.MapFS{"application.conf": {Data: []byte("db.port: 5432")}}
+		parseOpts := ParseOptions{Env: EnvMap{"CONFIG_FORCE_db_port": "5433"}}
+
+		got, err := Load(LoadOptions{FS: app, EnvOverrides: true, Parse: parseOpts})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5433)
+	})
+
+	t.Run("ignore the env overrides in Load if the env is disabled", func(t *testing.T) {
+		withReferences(t)
+		app := fstest.MapFS{"application.conf": {Data: []byte("db.port: 5432")}}
+		environ := []string{"CONFIG_FORCE_db_port=5433"}
+
+		got, err := Load(LoadOptions{FS: app, EnvOverrides: true, Environ: environ, Parse: ParseOptions{DisableEnv: true}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5432)
+	})
+}
@@ -1,6 +1,10 @@
 package hocon
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 // ParseError represents an error occurred while parsing a resource or string to a hocon configuration
 type ParseError struct {
@@ -8,12 +12,18 @@ type ParseError struct {
 	message string
 	line    int
 	column  int
+	cause   error
 }
 
 func (p *ParseError) Error() string {
 	return fmt.Sprintf("%s at: %d:%d, %s", p.errType, p.line, p.column, p.message)
 }
 
+// Unwrap method returns the underlying error of the ParseError if there is any
+func (p *ParseError) Unwrap() error {
+	return p.cause
+}
+
 func parseError(errType, message string, line, column int) *ParseError {
 	return &ParseError{errType: errType, message: message, line: line, column: column}
 }
@@ -34,6 +44,19 @@ func invalidSubstitutionError(message string, line, column int) *ParseError {
 	return parseError("invalid substitution!", message, line, column)
 }
 
+// substitutionError returns the error of a resolver at the position of the given substitution
+func substitutionError(substitution *Substitution, cause error) *ParseError {
+	var line, column int
+	if substitution.origin != nil {
+		line, column = substitution.origin.Line, substitution.origin.Column
+	}
+
+	err := invalidSubstitutionError(fmt.Sprintf("could not resolve substitution: %s, %s", substitution, cause), line, column)
+	err.cause = cause
+
+	return err
+}
+
 func invalidArrayError(message string, line, column int) *ParseError {
 	return parseError("invalid config array!", message, line, column)
 }
@@ -46,6 +69,19 @@ func invalidKeyError(key string, line, column int) *ParseError {
 	return parseError("invalid key!", fmt.Sprintf("%q is a forbidden character in keys", key), line, column)
 }
 
+func invalidIncludeError(message string, line, column int) *ParseError {
+	return parseError("invalid include!", message, line, column)
+}
+
+// includeCycleError returns the error of an include statement that includes a source of the given include chain again
+func includeCycleError(chain []string, line, column int) *ParseError {
+	return invalidIncludeError("include cycle detected: "+strings.Join(chain, " -> "), line, column)
+}
+
+func invalidJSONError(message string, line, column int) *ParseError {
+	return parseError("invalid json!", message, line, column)
+}
+
 func invalidValueError(message string, line, column int) *ParseError {
 	return parseError("invalid value!", message, line, column)
 }
@@ -69,3 +105,67 @@ func leadingCommaError(line, column int) *ParseError {
 func invalidConcatenationError() *ParseError {
 	return parseError("invalid concatenation!", "objects cannot be concatenated with other types", 0, 0)
 }
+
+// UnmarshalError represents an error occurred while decoding a configuration value into a Go value,
+// path is the full path of the configuration value and field is the Go field that the value is decoded into
+type UnmarshalError struct {
+	path    string
+	field   string
+	message string
+}
+
+func (u *UnmarshalError) Error() string {
+	return fmt.Sprintf("cannot unmarshal config value at path: %s into field: %s, %s", u.path, u.field, u.message)
+}
+
+func unmarshalError(path, field, message string) *UnmarshalError {
+	if path == "" {
+		path = "(root)"
+	}
+
+	return &UnmarshalError{path: path, field: field, message: message}
+}
+
+// UnusedKeysError represents the config keys that did not map to any struct field while decoding in the strict mode
+type UnusedKeysError struct {
+	paths []string
+}
+
+// Paths method returns the paths of the unused config keys
+func (u *UnusedKeysError) Paths() []string {
+	return slices.Clone(u.paths)
+}
+
+func (u *UnusedKeysError) Error() string {
+	return fmt.Sprintf("config keys not used by any struct field: %s", strings.Join(u.paths, ", "))
+}
+
+func unusedKeysError(paths []string) *UnusedKeysError {
+	return &UnusedKeysError{paths: paths}
+}
+
+// ValidationError represents all the violations of the validate struct tags and the values of the struct fields
+// that could not be decoded, the UnmarshalError of each value that could not be decoded is wrapped in the error
+type ValidationError struct {
+	violations []Violation
+	errs       []error
+}
+
+// Violations method returns the violations in the order of the struct fields
+func (v *ValidationError) Violations() []Violation {
+	return slices.Clone(v.violations)
+}
+
+// Unwrap method returns the UnmarshalError of each struct field value that could not be decoded
+func (v *ValidationError) Unwrap() []error {
+	return v.errs
+}
+
+func (v *ValidationError) Error() string {
+	messages := make([]string, 0, len(v.violations))
+	for _, violation := range v.violations {
+		messages = append(messages, violation.Path+" "+violation.Message)
+	}
+
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(messages, ", "))
+}
@@ -0,0 +1,311 @@
+package hocon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// the extensions of the sources that are not parsed as hocon, see the parseIncludeSource method
+const (
+	confExtension       = ".conf"
+	jsonExtension       = ".json"
+	propertiesExtension = ".properties"
+)
+
+// probedExtensions are the extensions tried for the includes without an extension, in the order of the merging,
+// so the values of the .conf file override the .json file and the .json file overrides the .properties file
+var probedExtensions = []string{propertiesExtension, jsonExtension, confExtension}
+
+// parseJSON parses the source as strict JSON (without the comments, substitutions, unquoted strings etc.),
+// the root value must be an object
+func (p *parser) parseJSON(src io.Reader) (*Object, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse resource: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	j := &jsonParser{parser: p, data: data, decoder: decoder}
+
+	token, err := j.token()
+	if err != nil {
+		return nil, err
+	}
+
+	if token != json.Delim('{') {
+		line, column := j.position(0)
+		return nil, invalidJSONError("the root value must be an object", line, column)
+	}
+
+	object, err := j.extractObject()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		line, column := j.position(decoder.InputOffset())
+		return nil, invalidJSONError("invalid token after the root object", line, column)
+	}
+
+	return object, nil
+}
+
+// jsonParser reads the tokens of a JSON source keeping the positions of the keys for the origins
+type jsonParser struct {
+	*parser
+	data    []byte
+	decoder *json.Decoder
+}
+
+func (j *jsonParser) token() (json.Token, error) {
+	token, err := j.decoder.Token()
+	if err == nil {
+		return token, nil
+	}
+
+	offset := j.decoder.InputOffset()
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset > 0 {
+		offset = syntaxErr.Offset - 1 // the offset of the syntax error is after the invalid character
+	}
+
+	line, column := j.position(offset)
+
+	return nil, invalidJSONError(err.Error(), line, column)
+}
+
+// extractObject extracts the object after its opening delimiter
+func (j *jsonParser) extractObject() (*Object, error) {
+	object := NewObject()
+
+	for j.decoder.More() {
+		line, column := j.position(j.keyOffset())
+
+		token, err := j.token()
+		if err != nil {
+			return nil, err
+		}
+
+		key := token.(string) // the decoder only allows the string keys
+
+		value, err := j.extractValue()
+		if err != nil {
+			return nil, err
+		}
+
+		object.Set(key, value)
+		object.setOrigin(key, j.origin(line, column))
+	}
+
+	if _, err := j.token(); err != nil { // closing delimiter
+		return nil, err
+	}
+
+	return object, nil
+}
+
+// extractArray extracts the array after its opening delimiter
+func (j *jsonParser) extractArray() (Array, error) {
+	array := Array{}
+
+	for j.decoder.More() {
+		value, err := j.extractValue()
+		if err != nil {
+			return nil, err
+		}
+
+		array = append(array, value)
+	}
+
+	if _, err := j.token(); err != nil { // closing delimiter
+		return nil, err
+	}
+
+	return array, nil
+}
+
+func (j *jsonParser) extractValue() (Value, error) {
+	token, err := j.token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		if t == '{' {
+			return j.extractObject()
+		}
+
+		return j.extractArray()
+	case json.Number:
+		if i, err := strconv.Atoi(string(t)); err == nil {
+			return Int(i), nil
+		}
+
+		f, err := t.Float64()
+		if err != nil {
+			line, column := j.position(j.decoder.InputOffset())
+			return nil, invalidJSONError(err.Error(), line, column)
+		}
+
+		return Float64(f), nil
+	case string:
+		return String(t), nil
+	case bool:
+		return Boolean(t), nil
+	}
+
+	return null, nil
+}
+
+// keyOffset returns the offset of the next key skipping the whitespaces and the comma before it
+func (j *jsonParser) keyOffset() int64 {
+	offset := j.decoder.InputOffset()
+	for offset < int64(len(j.data)) && (j.data[offset] == ',' || unicode.IsSpace(rune(j.data[offset]))) {
+		offset++
+	}
+
+	return offset
+}
+
+// position returns the line and the column of the given offset of the source
+func (j *jsonParser) position(offset int64) (int, int) {
+	offset = min(offset, int64(len(j.data)))
+	before := j.data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+
+	return line, int(offset) - bytes.LastIndexByte(before, '\n')
+}
+
+// parseProperties parses the source in the format of the Java properties files, the keys are split into the paths
+// at the periods and all the values are strings, a value is replaced with an object if a longer path has its key
+// as a prefix, e.g. "a=1" and "a.b=2" results in {a: {b: 2}}
+func (p *parser) parseProperties(src io.Reader) (*Object, error) {
+	object := NewObject()
+	reader := bufio.NewScanner(src)
+	lineNumber := 0
+
+	for reader.Scan() {
+		lineNumber++
+		keyLine := lineNumber
+
+		line := strings.TrimLeftFunc(reader.Text(), unicode.IsSpace)
+		column := len(reader.Text()) - len(line) + 1
+
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+
+		for hasLineContinuation(line) && reader.Scan() {
+			lineNumber++
+			line = line[:len(line)-1] + strings.TrimLeftFunc(reader.Text(), unicode.IsSpace)
+		}
+
+		key, value := splitProperty(line)
+		setProperty(object, strings.Split(unescapeProperty(key), dotToken), String(unescapeProperty(value)), p.origin(keyLine, column))
+	}
+
+	if err := reader.Err(); err != nil {
+		return nil, fmt.Errorf("could not parse resource: %w", err)
+	}
+
+	return object, nil
+}
+
+// hasLineContinuation reports whether the line ends with an odd number of backslashes
+func hasLineContinuation(line string) bool {
+	return (len(line)-len(strings.TrimRight(line, `\`)))%2 == 1
+}
+
+// splitProperty splits the line at the first unescaped '=', ':' or whitespace (and the whitespaces around it)
+func splitProperty(line string) (string, string) {
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\\':
+			i++
+		case c == '=' || c == ':' || unicode.IsSpace(rune(c)):
+			value := strings.TrimLeftFunc(line[i:], unicode.IsSpace)
+			if !unicode.IsSpace(rune(c)) || strings.HasPrefix(value, "=") || strings.HasPrefix(value, ":") {
+				value = strings.TrimLeftFunc(value[1:], unicode.IsSpace)
+			}
+
+			return line[:i], value
+		}
+	}
+
+	return line, ""
+}
+
+// unescapeProperty replaces the escape sequences of the properties with the characters they represent
+func unescapeProperty(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		i++
+
+		switch s[i] {
+		case 't':
+			sb.WriteByte('\t')
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 'f':
+			sb.WriteByte('\f')
+		case 'u':
+			if r, err := strconv.ParseUint(s[i+1:min(i+5, len(s))], 16, 32); err == nil && i+5 <= len(s) {
+				sb.WriteRune(rune(r))
+				i += 4
+			} else {
+				sb.WriteByte('u')
+			}
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+
+	return sb.String()
+}
+
+// setProperty sets the value at the given path of the object, the objects win over the other values at the same path
+func setProperty(object *Object, path []string, value Value, origin *Origin) {
+	key := path[0]
+	existing, _ := object.Get(key)
+
+	if len(path) == 1 {
+		if _, ok := existing.(*Object); !ok {
+			object.Set(key, value)
+			object.setOrigin(key, origin)
+		}
+
+		return
+	}
+
+	child, ok := existing.(*Object)
+	if !ok {
+		child = NewObject()
+		object.Set(key, child)
+		object.setOrigin(key, origin)
+	}
+
+	setProperty(child, path[1:], value, origin)
+}
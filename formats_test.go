@@ -0,0 +1,115 @@
+package hocon
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestExtensionProbing(t *testing.T) {
+	t.Run("merge the .properties, .json and .conf files of the include without an extension", func(t *testing.T) {
+		got, err := ParseString(`include "testdata/probe/app"`)
+		assertNoError(t, err)
+		expected := &Config{objectOf(
+			"a", String("conf"),
+			"b", String("json"),
+			"c", objectOf("d", String("properties")),
+			"list", Array{Int(1), Float64(2.5), Boolean(true), null},
+		)}
+		assertDeepEqual(t, got, expected)
+		origin, err := got.Origin("b")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "testdata/probe/app.json", Line: 3, Column: 3, IncludeChain: []string{""}})
+	})
+
+	t.Run("include the only existing file of the include without an extension", func(t *testing.T) {
+		got, err := ParseString(`include file("testdata/probe/db")`)
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5432)
+	})
+
+	t.Run("not probe the include with an extension", func(t *testing.T) {
+		got, err := ParseString("include \"testdata/probe/app.json\"")
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("a"), "json")
+	})
+
+	t.Run("ignore the optional include without any existing file", func(t *testing.T) {
+		got, err := ParseString("include \"testdata/probe/missing\"\na: 1")
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":1}`)
+	})
+
+	t.Run("return an error if none of the files of the required include exists", func(t *testing.T) {
+		got, err := ParseString(`include required("testdata/probe/missing")`)
+		assertNil(t, got)
+		message := "could not parse resource: none of testdata/probe/missing.conf, testdata/probe/missing.json " +
+			"and testdata/probe/missing.properties exists: file does not exist"
+		assertError(t, err, errors.New(message))
+	})
+
+	t.Run("parse the included json files in strict mode", func(t *testing.T) {
+		got, err := ParseString(`include "testdata/probe/comment.json"`)
+		assertNil(t, got)
+		assertError(t, err, invalidJSONError("invalid character '/' looking for beginning of value", 2, 3))
+	})
+}
+
+func TestParseJSON(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		input    string
+		expected error
+	}{
+		{"array as the root value", "[1]", invalidJSONError("the root value must be an object", 1, 1)},
+		{"unquoted string", `{"a": b}`, invalidJSONError("invalid character 'b' looking for beginning of value", 1, 7)},
+		{"substitution", "{\n\"a\": ${b}}", invalidJSONError("invalid character '$' looking for beginning of value", 2, 6)},
+		{"unclosed object", `{"a": 1`, invalidJSONError("unexpected end of JSON input", 1, 7)},
+		{"value after the root object", `{"a": 1} {}`, invalidJSONError("invalid token after the root object", 1, 11)},
+	}
+
+	for _, tc := range testCases {
+		t.Run("return an error for the "+tc.name, func(t *testing.T) {
+			got, err := newParser(nil).parseJSON(strings.NewReader(tc.input))
+			assertNil(t, got)
+			assertError(t, err, tc.expected)
+		})
+	}
+
+	t.Run("parse the values in the order of the keys", func(t *testing.T) {
+		got, err := newParser(nil).parseJSON(strings.NewReader(`{"b": {"c": [1, "x"]}, "a": -1.5e3, "d": false}`))
+		assertNoError(t, err)
+		assertDeepEqual(t, got, objectOf("b", objectOf("c", Array{Int(1), String("x")}), "a", Float64(-1500), "d", Boolean(false)))
+	})
+}
+
+func TestParseProperties(t *testing.T) {
+	t.Run("parse the keys as the paths and the values as strings", func(t *testing.T) {
+		input := "# comment\n! comment\n\n  db.host = localhost\ndb.port:5432\nname app name\nempty\n"
+		got, err := newParser(nil).parseProperties(strings.NewReader(input))
+		assertNoError(t, err)
+		expected := objectOf(
+			"db", objectOf("host", String("localhost"), "port", String("5432")),
+			"name", String("app name"),
+			"empty", String(""),
+		)
+		assertDeepEqual(t, got, expected)
+		origin, ok := got.Get("db")
+		assertEquals(t, ok, true)
+		portOrigin, _ := origin.(*Object).Origin("port")
+		assertDeepEqual(t, portOrigin, &Origin{Line: 5, Column: 1})
+	})
+
+	t.Run("join the continued lines and replace the escape sequences", func(t *testing.T) {
+		input := "list = a,\\\n       b\nkey\\ with\\=chars = tab\\there \\u00e9\\\\\n"
+		got, err := newParser(nil).parseProperties(strings.NewReader(input))
+		assertNoError(t, err)
+		assertDeepEqual(t, got, objectOf("list", String("a,b"), "key with=chars", String("tab\there é\\")))
+	})
+
+	t.Run("keep the object if a key is also defined as a value", func(t *testing.T) {
+		got, err := newParser(nil).parseProperties(strings.NewReader("a=1\na.b=2\na=3\n"))
+		assertNoError(t, err)
+		assertDeepEqual(t, got, objectOf("a", objectOf("b", String("2"))))
+	})
+}
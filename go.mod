@@ -1,3 +1,3 @@
-module github.com/kulikov/go-hocon
+module github.com/kulikov/go-hocon/v2
 
 go 1.23.1
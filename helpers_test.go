@@ -50,13 +50,77 @@ func assertError(t *testing.T, err, expected error) {
 	}
 }
 
+// assertDeepEqual compares the given values ignoring the origins of the object keys and the substitutions
 func assertDeepEqual(t *testing.T, got, expected interface{}) {
 	t.Helper()
-	if !reflect.DeepEqual(got, expected) {
+	if !reflect.DeepEqual(withoutOrigins(got), withoutOrigins(expected)) {
 		fail(t, got, expected)
 	}
 }
 
+// withoutOrigins returns a copy of the given configuration or value without the origins of the object keys
+// and the substitutions
+func withoutOrigins(i interface{}) interface{} {
+	switch v := i.(type) {
+	case *Config:
+		if v == nil {
+			return v
+		}
+
+		return &Config{root: withoutOrigins(v.root).(Value)}
+	case *Object:
+		if v == nil {
+			return v
+		}
+
+		object := &Object{keys: v.keys, items: make(map[string]Value, len(v.items))}
+		for key, value := range v.items {
+			if value != nil {
+				value = withoutOrigins(value).(Value)
+			}
+
+			object.items[key] = value
+		}
+
+		return object
+	case Array:
+		return Array(withoutOriginsOfValues(v))
+	case concatenation:
+		return concatenation(withoutOriginsOfValues(v))
+	case *valueWithAlternative:
+		if v == nil {
+			return v
+		}
+
+		return &valueWithAlternative{value: withoutOrigins(v.value).(Value), alternative: withoutOrigins(v.alternative).(Value)}
+	case *Substitution:
+		if v == nil {
+			return v
+		}
+
+		return &Substitution{path: v.path, optional: v.optional, prefix: v.prefix}
+	}
+
+	return i
+}
+
+func withoutOriginsOfValues(values []Value) []Value {
+	if values == nil {
+		return nil
+	}
+
+	result := make([]Value, len(values))
+	for i, value := range values {
+		if value != nil {
+			value = withoutOrigins(value).(Value)
+		}
+
+		result[i] = value
+	}
+
+	return result
+}
+
 func assertNil(t *testing.T, i interface{}) {
 	t.Helper()
 	if !isNil(i) {
@@ -64,6 +128,18 @@ func assertNil(t *testing.T, i interface{}) {
 	}
 }
 
+// objectOf creates an Object from the given key and value pairs, keeping the order of the keys
+func objectOf(keysAndValues ...interface{}) *Object {
+	object := NewObject()
+
+	for i := 0; i < len(keysAndValues); i += 2 {
+		value, _ := keysAndValues[i+1].(Value)
+		object.Set(keysAndValues[i].(string), value)
+	}
+
+	return object
+}
+
 func advanceScanner(t *testing.T, parser *parser, target string) {
 	t.Helper()
 	for parser.scanner.TokenText() != target {
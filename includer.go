@@ -0,0 +1,229 @@
+package hocon
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// IncludeKind is the kind of the include statement
+type IncludeKind int
+
+// IncludeKind constants
+const (
+	BareInclude      IncludeKind = iota // include "name"
+	FileInclude                         // include file("name")
+	ClasspathInclude                    // include classpath("name")
+	URLInclude                          // include url("name")
+	GlobInclude                         // include glob("conf.d/*.conf")
+)
+
+// String method returns the name of the IncludeKind as written in the include statements
+func (k IncludeKind) String() string {
+	switch k {
+	case FileInclude:
+		return "file"
+	case ClasspathInclude:
+		return "classpath"
+	case URLInclude:
+		return "url"
+	case GlobInclude:
+		return "glob"
+	}
+
+	return "bare"
+}
+
+// Include describes an include statement of the parsed source
+type Include struct {
+	Kind     IncludeKind
+	Location string // the quoted string of the include statement, e.g. "db.conf" for include file("db.conf")
+	Required bool   // whether the include is wrapped in required(...)
+	Parent   string // the name of the source that contains the include statement, empty for the strings
+	BaseDir  string // the directory that the relative locations are resolved from
+}
+
+// IncludeSource is a source opened by an Includer
+type IncludeSource struct {
+	// Name of the source, the includes of the source are resolved relative to it, it is used in the origins and
+	// compared with the names of the including sources to detect the include cycles,
+	// the sources are parsed as strict JSON if the name has the .json extension, as properties if it has
+	// the .properties extension and as hocon otherwise
+	Name   string
+	Reader io.ReadCloser
+}
+
+// Includer opens the sources of the include statements, the sources are parsed and merged in the returned order,
+// the locations without the .conf, .json or .properties extension are included with each of the extensions
+type Includer interface {
+	// Include returns the sources of the given include, returns an error wrapping fs.ErrNotExist if there are no
+	// sources, the include is ignored then unless it is required
+	Include(include Include) ([]IncludeSource, error)
+}
+
+// IncluderFunc type is an adapter to use an ordinary function as an Includer
+type IncluderFunc func(include Include) ([]IncludeSource, error)
+
+// Include method calls f(include)
+func (f IncluderFunc) Include(include Include) ([]IncludeSource, error) {
+	return f(include)
+}
+
+// FileIncluder function returns the default Includer that opens the files of the bare, file(...) and classpath(...)
+// includes relative to the base directory and the files matching the patterns of the glob(...) includes in lexical
+// order, the url(...) includes are not supported
+func FileIncluder() Includer {
+	return fileIncluder("")
+}
+
+// fileIncluder returns the FileIncluder that only opens the files inside the given root directory if it is not empty
+func fileIncluder(root string) Includer {
+	return IncluderFunc(func(include Include) ([]IncludeSource, error) {
+		if include.Kind == URLInclude {
+			return nil, fmt.Errorf("url includes are not supported: %s", include.Location)
+		}
+
+		name := path.Clean(include.Location)
+		if !path.IsAbs(name) {
+			name = path.Join(include.BaseDir, name)
+		}
+
+		names := []string{name}
+
+		if include.Kind == GlobInclude {
+			matches, err := filepath.Glob(name)
+			if err != nil {
+				return nil, err
+			}
+
+			names = matches
+		}
+
+		open := func(name string) (io.ReadCloser, error) { return os.Open(name) }
+
+		if root != "" {
+			realNames := make(map[string]string, len(names))
+
+			for _, name := range names {
+				realName, err := checkIncludeRoot(root, name)
+				if err != nil {
+					return nil, err
+				}
+
+				realNames[name] = realName
+			}
+
+			// open the checked real paths, so a symbolic link that replaces the file after the check is not followed
+			open = func(name string) (io.ReadCloser, error) { return os.Open(realNames[name]) }
+		}
+
+		return openSources(include, names, open)
+	})
+}
+
+// openSources opens the sources of the given names in lexical order, returns an error wrapping fs.ErrNotExist
+// if no files match the pattern of a glob(...) include
+func openSources(include Include, names []string, open func(name string) (io.ReadCloser, error)) ([]IncludeSource, error) {
+	if include.Kind == GlobInclude && len(names) == 0 {
+		return nil, fmt.Errorf("no files match the pattern: %s: %w", include.Location, fs.ErrNotExist)
+	}
+
+	slices.Sort(names)
+
+	sources := make([]IncludeSource, 0, len(names))
+
+	for _, name := range names {
+		reader, err := open(name)
+		if err != nil {
+			closeSources(sources)
+
+			return nil, err
+		}
+
+		sources = append(sources, IncludeSource{Name: name, Reader: reader})
+	}
+
+	return sources, nil
+}
+
+// checkIncludeRoot returns the real path of the file of the given name with the symbolic links evaluated,
+// returns an error if the file is outside of the given root directory or it is a symbolic link
+// (or in a linked directory) that leaves the root directory
+func checkIncludeRoot(root, name string) (string, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+
+	absName, err := filepath.Abs(name)
+	if err != nil {
+		return "", err
+	}
+
+	if !isInDir(absRoot, absName) {
+		return "", fmt.Errorf("%s is outside of the include root: %s", name, root)
+	}
+
+	realRoot, err := filepath.EvalSymlinks(absRoot)
+	if err != nil {
+		return "", err
+	}
+
+	realName, err := filepath.EvalSymlinks(absName)
+	if err != nil {
+		return "", err
+	}
+
+	if !isInDir(realRoot, realName) {
+		return "", fmt.Errorf("%s is linked outside of the include root: %s", name, root)
+	}
+
+	return realName, nil
+}
+
+// isInDir reports whether the given absolute path is inside the given absolute directory
+func isInDir(dir, name string) bool {
+	rel, err := filepath.Rel(dir, name)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// closeSources closes the readers of the opened sources when the others cannot be opened
+func closeSources(sources []IncludeSource) {
+	for _, source := range sources {
+		_ = source.Reader.Close()
+	}
+}
+
+// FSIncluder function returns an Includer that opens the files of the bare, file(...), classpath(...) and glob(...)
+// includes in the given file system (e.g. an embed.FS) relative to the base directory, the absolute locations are resolved
+// from the root of the file system and the url(...) includes are not supported
+func FSIncluder(fsys fs.FS) Includer {
+	return IncluderFunc(func(include Include) ([]IncludeSource, error) {
+		if include.Kind == URLInclude {
+			return nil, fmt.Errorf("url includes are not supported: %s", include.Location)
+		}
+
+		name := path.Join(include.BaseDir, include.Location)
+		if path.IsAbs(include.Location) {
+			name = strings.TrimPrefix(path.Clean(include.Location), "/")
+		}
+
+		names := []string{name}
+
+		if include.Kind == GlobInclude {
+			matches, err := fs.Glob(fsys, name)
+			if err != nil {
+				return nil, err
+			}
+
+			names = matches
+		}
+
+		return openSources(include, names, func(name string) (io.ReadCloser, error) { return fsys.Open(name) })
+	})
+}
@@ -0,0 +1,263 @@
+package hocon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestIncluder(t *testing.T) {
+	sources := map[string]string{
+		"db.conf":         "db { host: localhost, port: 5432 }",
+		"nested/app.conf": "include \"db.conf\"\nname: app",
+		"nested/db.conf":  "db.port: 5433",
+	}
+
+	var includes []Include
+
+	memory := IncluderFunc(func(include Include) ([]IncludeSource, error) {
+		includes = append(includes, include)
+
+		name := strings.TrimPrefix(include.BaseDir+"/"+include.Location, "./")
+		source, ok := sources[name]
+		if !ok {
+			return nil, fmt.Errorf("%s: %w", name, fs.ErrNotExist)
+		}
+
+		return []IncludeSource{{Name: name, Reader: io.NopCloser(strings.NewReader(source))}}, nil
+	})
+	opts := ParseOptions{Includer: memory}
+
+	t.Run("include the sources opened by the includer", func(t *testing.T) {
+		includes = nil
+		got, err := ParseStringWithOptions("include classpath(\"db.conf\")\ndb.port: 6432", opts)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"db":{"host":"localhost", "port":6432}}`)
+		assertDeepEqual(t, includes, []Include{{Kind: ClasspathInclude, Location: "db.conf", BaseDir: "."}})
+	})
+
+	t.Run("resolve the includes of the included sources relative to their names", func(t *testing.T) {
+		includes = nil
+		got, err := ParseStringWithOptions(`include required(file("nested/app.conf"))`, opts)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"db":{"port":5433}, "name":"app"}`)
+		expected := []Include{
+			{Kind: FileInclude, Location: "nested/app.conf", Required: true, BaseDir: "."},
+			{Kind: BareInclude, Location: "db.conf", Parent: "nested/app.conf", BaseDir: "nested"},
+		}
+		assertDeepEqual(t, includes, expected)
+		origin, err := got.Origin("db.port")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "nested/db.conf", Line: 1, Column: 4, IncludeChain: []string{"", "nested/app.conf"}})
+	})
+
+	t.Run("pass the url includes to the includer", func(t *testing.T) {
+		includes = nil
+		_, err := ParseStringWithOptions(`include url("http://localhost/app.conf")`, opts)
+		assertNoError(t, err)
+		assertDeepEqual(t, includes, []Include{{Kind: URLInclude, Location: "http://localhost/app.conf", BaseDir: "."}})
+	})
+
+	t.Run("ignore the missing optional includes", func(t *testing.T) {
+		got, err := ParseStringWithOptions("include \"missing.conf\"\na: 1", opts)
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":1}`)
+	})
+
+	t.Run("return an error if a required include is missing", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include required("missing.conf")`, opts)
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not parse resource: missing.conf: file does not exist"))
+		assertEquals(t, errors.Is(err, fs.ErrNotExist), true)
+	})
+
+	t.Run("merge all the sources returned by the includer in order", func(t *testing.T) {
+		includer := IncluderFunc(func(include Include) ([]IncludeSource, error) {
+			return []IncludeSource{
+				{Name: "first.conf", Reader: io.NopCloser(strings.NewReader("a: 1, b { c: 1 }"))},
+				{Name: "second.conf", Reader: io.NopCloser(strings.NewReader("a: 2, b { d: 2 }"))},
+			}, nil
+		})
+		got, err := ParseStringWithOptions(`include "all.conf"`, ParseOptions{Includer: includer})
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":2, "b":{"c":1, "d":2}}`)
+	})
+}
+
+func TestFileIncluder(t *testing.T) {
+	t.Run("open the file relative to the base directory", func(t *testing.T) {
+		got, err := FileIncluder().Include(Include{Kind: ClasspathInclude, Location: "nested/y.conf", BaseDir: "testdata"})
+		assertNoError(t, err)
+		assertEquals(t, len(got), 1)
+		assertEquals(t, got[0].Name, "testdata/nested/y.conf")
+		assertNoError(t, got[0].Reader.Close())
+	})
+
+	t.Run("return an error wrapping fs.ErrNotExist if the file does not exist", func(t *testing.T) {
+		got, err := FileIncluder().Include(Include{Location: "missing.conf", BaseDir: "testdata"})
+		assertNil(t, got)
+		assertEquals(t, errors.Is(err, fs.ErrNotExist), true)
+	})
+
+	t.Run("return an error for the url includes", func(t *testing.T) {
+		got, err := FileIncluder().Include(Include{Kind: URLInclude, Location: "http://localhost/a.conf"})
+		assertNil(t, got)
+		assertError(t, err, errors.New("url includes are not supported: http://localhost/a.conf"))
+	})
+}
+
+func TestFSIncluder(t *testing.T) {
+	fsys := fstest.MapFS{"conf/db.conf": {Data: []byte("db.port: 5432")}}
+
+	t.Run("open the file relative to the base directory in the file system", func(t *testing.T) {
+		got, err := FSIncluder(fsys).Include(Include{Location: "db.conf", BaseDir: "conf"})
+		assertNoError(t, err)
+		assertEquals(t, len(got), 1)
+		assertEquals(t, got[0].Name, "conf/db.conf")
+		assertNoError(t, got[0].Reader.Close())
+	})
+
+	t.Run("open the absolute location from the root of the file system", func(t *testing.T) {
+		got, err := FSIncluder(fsys).Include(Include{Location: "/conf/db.conf", BaseDir: "other"})
+		assertNoError(t, err)
+		assertEquals(t, got[0].Name, "conf/db.conf")
+		assertNoError(t, got[0].Reader.Close())
+	})
+
+	t.Run("return an error for the url includes", func(t *testing.T) {
+		got, err := FSIncluder(fsys).Include(Include{Kind: URLInclude, Location: "http://localhost/a.conf"})
+		assertNil(t, got)
+		assertError(t, err, errors.New("url includes are not supported: http://localhost/a.conf"))
+	})
+}
+
+func TestSandboxedIncludes(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	writeFile(t, filepath.Join(dir, "secret.conf"), "secret: 42")
+	writeFile(t, filepath.Join(root, "db.conf"), "db.port: 5432")
+	writeFile(t, filepath.Join(root, "nested", "app.conf"), `include "../db.conf"`)
+	assertNoError(t, os.Symlink(filepath.Join(dir, "secret.conf"), filepath.Join(root, "link.conf")))
+	opts := ParseOptions{BaseDir: root, IncludeRoot: root}
+
+	t.Run("include the files inside the root directory", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "nested/app.conf"`, opts)
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5432)
+	})
+
+	t.Run("return an error if the include leaves the root directory", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "../secret.conf"`, opts)
+		assertNil(t, got)
+		name := filepath.Join(dir, "secret.conf")
+		assertError(t, err, fmt.Errorf("could not parse resource: %s is outside of the include root: %s", name, root))
+	})
+
+	t.Run("return an error if the included file is linked outside of the root directory", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "link.conf"`, opts)
+		assertNil(t, got)
+		name := filepath.Join(root, "link.conf")
+		assertError(t, err, fmt.Errorf("could not parse resource: %s is linked outside of the include root: %s", name, root))
+	})
+
+	t.Run("return the real path of the linked file inside the root directory to open it", func(t *testing.T) {
+		assertNoError(t, os.Symlink(filepath.Join(root, "db.conf"), filepath.Join(root, "db-link.conf")))
+		realRoot, err := filepath.EvalSymlinks(root)
+		assertNoError(t, err)
+		got, err := checkIncludeRoot(root, filepath.Join(root, "db-link.conf"))
+		assertNoError(t, err)
+		assertEquals(t, got, filepath.Join(realRoot, "db.conf"))
+	})
+
+	t.Run("return an error if the absolute include is outside of the root directory", func(t *testing.T) {
+		got, err := ParseStringWithOptions(fmt.Sprintf("include %q", filepath.Join(dir, "secret.conf")), opts)
+		assertNil(t, got)
+		name := filepath.Join(dir, "secret.conf")
+		assertError(t, err, fmt.Errorf("could not parse resource: %s is outside of the include root: %s", name, root))
+	})
+
+	t.Run("ignore the missing optional includes inside the root directory", func(t *testing.T) {
+		got, err := ParseStringWithOptions("include \"missing.conf\"\na: 1", opts)
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("a"), 1)
+	})
+
+	t.Run("return an error if the includes are nested deeper than the maximum depth", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "nested/app.conf"`, ParseOptions{BaseDir: root, MaxIncludeDepth: 1})
+		assertNil(t, got)
+		assertError(t, err, invalidIncludeError("maximum include depth of 1 is exceeded", 1, 9))
+	})
+
+	t.Run("include the files up to the maximum depth", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "nested/app.conf"`, ParseOptions{BaseDir: root, MaxIncludeDepth: 2})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5432)
+	})
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	assertNoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	assertNoError(t, os.WriteFile(name, []byte(content), 0o600))
+}
+
+func TestGlobIncludes(t *testing.T) {
+	t.Run("merge the files matching the pattern in lexical order", func(t *testing.T) {
+		got, err := ParseString("name: default\ninclude glob(\"testdata/conf.d/*.conf\")")
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"name":"first", "db":{"host":"localhost", "port":6432}}`)
+		origin, err := got.Origin("db.host")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "testdata/conf.d/10-db.conf", Line: 1, Column: 6, IncludeChain: []string{""}})
+		origin, err = got.Origin("db.port")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "testdata/conf.d/20-override.conf", Line: 1, Column: 4, IncludeChain: []string{""}})
+	})
+
+	t.Run("parse the matching files in the format of their extensions", func(t *testing.T) {
+		got, err := ParseString(`include glob("testdata/conf.d/*0-*")`)
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("name"), "json")
+	})
+
+	t.Run("match the files in the file system", func(t *testing.T) {
+		got, err := ParseFS(testdataFS, "testdata/conf.d/20-override.conf")
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 6432)
+		fsys := fstest.MapFS{
+			"app.conf":        {Data: []byte(`include glob("conf.d/*.conf")`)},
+			"conf.d/b.conf":   {Data: []byte("a: b")},
+			"conf.d/a.conf":   {Data: []byte("a: a, b: a")},
+			"conf.d/c.ignore": {Data: []byte("a: c")},
+		}
+		got, err = ParseFS(fsys, "app.conf")
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":"b", "b":"a"}`)
+	})
+
+	t.Run("ignore the optional pattern without any matching file", func(t *testing.T) {
+		got, err := ParseString("include glob(\"testdata/conf.d/*.missing\")\na: 1")
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":1}`)
+	})
+
+	t.Run("return an error if no files match the pattern of the required include", func(t *testing.T) {
+		got, err := ParseString(`include required(glob("testdata/conf.d/*.missing"))`)
+		assertNil(t, got)
+		expected := "could not parse resource: no files match the pattern: testdata/conf.d/*.missing: file does not exist"
+		assertError(t, err, errors.New(expected))
+	})
+
+	t.Run("return an error if a matching file is outside of the include root", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include glob("../*.conf")`, ParseOptions{BaseDir: "testdata/conf.d", IncludeRoot: "testdata/conf.d"})
+		assertNil(t, got)
+		expected := "could not parse resource: testdata/a.conf is outside of the include root: testdata/conf.d"
+		assertError(t, err, errors.New(expected))
+	})
+}
@@ -0,0 +1,294 @@
+package hocon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultApplication = "application.conf"
+	referenceName      = "reference.conf"
+)
+
+var (
+	referencesMu sync.Mutex
+	references   []ClasspathRoot
+)
+
+// RegisterReference function registers the file system of a library that contains its reference.conf (the defaults
+// of its configuration) at the root, usually an embed.FS registered in an init function, the Load function merges
+// the reference configs of all the registered libraries in the order of the registration, it panics if the name
+// is already registered or the file system is nil
+func RegisterReference(name string, fsys fs.FS) {
+	referencesMu.Lock()
+	defer referencesMu.Unlock()
+
+	if fsys == nil {
+		panic("hocon: RegisterReference file system is nil for " + name)
+	}
+
+	if slices.ContainsFunc(references, func(root ClasspathRoot) bool { return root.Name == name }) {
+		panic("hocon: RegisterReference called twice for " + name)
+	}
+
+	references = append(references, ClasspathRoot{Name: name, FS: fsys})
+}
+
+// registeredReferences returns the roots of the registered reference configs in the order of the registration
+func registeredReferences() []ClasspathRoot {
+	referencesMu.Lock()
+	defer referencesMu.Unlock()
+
+	return slices.Clone(references)
+}
+
+// LoadOptions configures the Load function
+type LoadOptions struct {
+	// Application is the path of the application config, "application.conf" by default, it is optional,
+	// so the references are used alone if it does not exist
+	Application string
+	// FS is the file system that the application config and its includes are read from, the files of the operating
+	// system are read if it is nil
+	FS fs.FS
+	// Overrides take precedence over the application config, the earlier ones over the later ones
+	Overrides []*Config
+	// Profiles are the active profiles, the overlay of each profile is loaded from the application config name with
+	// the profile inserted before the extension (e.g. "application.staging.conf"), it is optional and takes precedence
+	// over the application config and the overlays of the earlier profiles
+	Profiles []string
+	// ProfilesEnv is the name of the environment variable that contains the comma separated active profiles,
+	// it is used if Profiles is empty and looked up in the environment of the Parse options
+	ProfilesEnv string
+	// EnvOverrides enables the overrides of the CONFIG_FORCE_ environment variables (see the EnvOverrides function),
+	// they take precedence over the application config but not over the Overrides, they are disabled by
+	// the DisableEnv of the Parse options
+	EnvOverrides bool
+	// Environ is the environment (in the "key=value" format of os.Environ) that the env overrides are read from,
+	// the variables of the Env of the Parse options if it is an EnvMap or the environment of the process are used
+	// if it is nil
+	Environ []string
+	// Parse configures the parsing of the application and the reference configs and the resolution of the merged
+	// config, the classpath(...) includes are searched in the registered references
+	Parse ParseOptions
+}
+
+// Load function loads the configuration of the application like the ConfigFactory.load of the Lightbend config,
+// the overrides, the environment overrides, the profile overlays, the application config and the reference configs
+// of the registered libraries are merged in this order of precedence with the WithFallback method and
+// the substitutions are resolved once at the end, so the references can refer to the values of the application
+// config and vice versa
+func Load(opts LoadOptions) (*Config, error) {
+	config, _, err := LoadProfiles(opts)
+
+	return config, err
+}
+
+// LoadProfiles function loads the configuration of the application like the Load function and also returns the names
+// of the profile overlays that were found in the order of precedence, from the lowest to the highest
+func LoadProfiles(opts LoadOptions) (*Config, []string, error) {
+	roots := registeredReferences()
+
+	parseOpts := opts.Parse
+	parseOpts.Includer = &ClasspathIncluder{Roots: roots, Fallback: opts.includer()}
+
+	env, err := opts.envOverrides()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	application, overlays, err := loadApplication(opts, parseOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reference, err := loadReferences(parseOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := NewObject().ToConfig()
+	for _, override := range opts.Overrides {
+		config = config.WithFallback(override)
+	}
+
+	config, err = config.WithFallback(env).WithFallback(application).WithFallback(reference).Resolve(parseOpts.resolveOptions())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return config, overlays, nil
+}
+
+// includer returns the Includer of the application config (and of the other includes of the references)
+func (o LoadOptions) includer() Includer {
+	switch {
+	case o.Parse.Includer != nil:
+		return o.Parse.Includer
+	case o.FS != nil:
+		return FSIncluder(o.FS)
+	}
+
+	return fileIncluder(o.Parse.IncludeRoot)
+}
+
+// profiles returns the active profiles of the options or of the environment variable
+func (o LoadOptions) profiles() ([]string, error) {
+	profiles := o.Profiles
+	if len(profiles) == 0 && o.ProfilesEnv != "" {
+		env, _ := o.Parse.resolveOptions().lookupEnv(o.ProfilesEnv)
+		profiles = strings.Split(env, ",")
+	}
+
+	active := make([]string, 0, len(profiles))
+
+	for _, profile := range profiles {
+		profile = strings.TrimSpace(profile)
+		if profile == "" {
+			continue
+		}
+
+		if strings.ContainsAny(profile, `/\`) {
+			return nil, fmt.Errorf("invalid profile: %q", profile)
+		}
+
+		active = append(active, profile)
+	}
+
+	return active, nil
+}
+
+// loadApplication parses the application config and the overlays of the active profiles without resolving them
+// and merges them, returns the names of the found overlays
+func loadApplication(opts LoadOptions, parseOpts ParseOptions) (*Config, []string, error) {
+	name := opts.Application
+	if name == "" {
+		name = defaultApplication
+	}
+
+	profiles, err := opts.profiles()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	application, err := loadOptional(name, opts.FS, parseOpts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if application == nil {
+		application = NewObject().ToConfig()
+	}
+
+	var overlays []string
+
+	for _, profile := range profiles {
+		overlayName := profileOverlay(name, profile)
+
+		overlay, err := loadOptional(overlayName, opts.FS, parseOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if overlay != nil {
+			overlays = append(overlays, overlayName)
+			application = overlay.WithFallback(application)
+		}
+	}
+
+	return application, overlays, nil
+}
+
+// profileOverlay returns the name of the overlay of the given profile, e.g. "application.staging.conf"
+func profileOverlay(name, profile string) string {
+	extension := path.Ext(name)
+
+	return strings.TrimSuffix(name, extension) + "." + profile + extension
+}
+
+// loadOptional parses the config of the given name without resolving it, returns nil if it does not exist
+func loadOptional(name string, fsys fs.FS, parseOpts ParseOptions) (*Config, error) {
+	var (
+		file io.ReadCloser
+		err  error
+	)
+
+	if fsys != nil {
+		file, err = fsys.Open(name)
+	} else {
+		file, err = os.Open(name)
+	}
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("could not parse resource: %w", err)
+	}
+
+	defer file.Close()
+
+	config, err := newParserWithOptions(file, name, parseOpts).parseUnresolved()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := rootObject(config, name); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// loadReferences parses the reference configs of the registered libraries without resolving them and merges them
+// with the earlier registered ones taking precedence
+func loadReferences(parseOpts ParseOptions) (*Config, error) {
+	parseOpts.BaseDir = ""
+	parseOpts.OriginDescription = ""
+
+	sources, err := parseOpts.Includer.Include(Include{Kind: ClasspathInclude, Location: referenceName})
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return NewObject().ToConfig(), nil
+		}
+
+		return nil, fmt.Errorf("could not parse resource: %w", err)
+	}
+
+	defer closeSources(sources)
+
+	reference := NewObject()
+
+	for _, source := range sources {
+		config, err := newParserWithOptions(source.Reader, source.Name, parseOpts).parseUnresolved()
+		if err != nil {
+			return nil, err
+		}
+
+		object, err := rootObject(config, source.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeObjects(reference, object)
+	}
+
+	return reference.ToConfig(), nil
+}
+
+// rootObject returns the root object of the loaded config, returns an error if the root is an array
+func rootObject(config *Config, name string) (*Object, error) {
+	object, ok := config.root.(*Object)
+	if !ok {
+		return nil, fmt.Errorf("could not load %s: the root value must be an object", name)
+	}
+
+	return object, nil
+}
@@ -0,0 +1,166 @@
+package hocon
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+// withReferences replaces the registered references with the given ones for the duration of the test
+func withReferences(t *testing.T, roots ...ClasspathRoot) {
+	t.Helper()
+
+	referencesMu.Lock()
+	registered := references
+	references = nil
+	referencesMu.Unlock()
+
+	t.Cleanup(func() {
+		referencesMu.Lock()
+		references = registered
+		referencesMu.Unlock()
+	})
+
+	for _, root := range roots {
+		RegisterReference(root.Name, root.FS)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	db := fstest.MapFS{
+		"reference.conf": {Data: []byte(`db { host: localhost, port: 5432, url: "postgres://"${db.host}":"${db.port} }`)},
+	}
+	http := fstest.MapFS{
+		"reference.conf":   {Data: []byte("include \"http/server.conf\"\ndb.port: 6432")},
+		"http/server.conf": {Data: []byte("http.port: 8080")},
+	}
+	app := fstest.MapFS{
+		"application.conf": {Data: []byte("include classpath(\"http/server.conf\")\ninclude \"db.conf\"\nname: app")},
+		"db.conf":          {Data: []byte("db.host: db.internal")},
+	}
+
+	t.Run("merge the overrides, the application and the references and resolve them once", func(t *testing.T) {
+		withReferences(t, ClasspathRoot{Name: "db", FS: db}, ClasspathRoot{Name: "http", FS: http})
+		override, err := ParseString("http.port: 9090")
+		assertNoError(t, err)
+		got, err := Load(LoadOptions{FS: app, Overrides: []*Config{override}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("db.url"), "postgres://db.internal:5432")
+		assertEquals(t, got.GetIntOrPanic("http.port"), 9090)
+		assertEquals(t, got.GetStringOrPanic("name"), "app")
+		origin, err := got.Origin("db.port")
+		assertNoError(t, err)
+		assertEquals(t, origin.String(), "classpath:db!/reference.conf:1:23")
+	})
+
+	t.Run("give the earlier overrides precedence over the later ones", func(t *testing.T) {
+		withReferences(t)
+		first, err := ParseString("a: 1")
+		assertNoError(t, err)
+		second, err := ParseString("a: 2, b: 2")
+		assertNoError(t, err)
+		got, err := Load(LoadOptions{Application: "testdata/missing.conf", Overrides: []*Config{first, second}})
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":1, "b":2}`)
+	})
+
+	t.Run("use the references alone if the application config does not exist", func(t *testing.T) {
+		withReferences(t, ClasspathRoot{Name: "db", FS: db})
+		got, err := Load(LoadOptions{FS: app, Application: "missing.conf"})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("db.url"), "postgres://localhost:5432")
+	})
+
+	t.Run("load the application config of the given path from the operating system", func(t *testing.T) {
+		withReferences(t)
+		got, err := Load(LoadOptions{Application: "testdata/x.conf"})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "y", String("foo"), "x", Int(7))})
+	})
+
+	t.Run("return an error if a substitution cannot be resolved", func(t *testing.T) {
+		withReferences(t, ClasspathRoot{Name: "db", FS: fstest.MapFS{"reference.conf": {Data: []byte("a: ${b}")}}})
+		got, err := Load(LoadOptions{FS: app, Parse: ParseOptions{DisableEnv: true}})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not resolve substitution: ${b} to a value"))
+	})
+
+	t.Run("return an error if the root of a reference is an array", func(t *testing.T) {
+		withReferences(t, ClasspathRoot{Name: "list", FS: fstest.MapFS{"reference.conf": {Data: []byte("[1]")}}})
+		got, err := Load(LoadOptions{FS: app})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not load classpath:list!/reference.conf: the root value must be an object"))
+	})
+}
+
+func TestLoadProfiles(t *testing.T) {
+	app := fstest.MapFS{
+		"conf/application.conf":         {Data: []byte("db { host: localhost, port: 5432 }\nname: app")},
+		"conf/application.staging.conf": {Data: []byte("db.host: staging.internal\ndb.port: 6432")},
+		"conf/application.eu.conf":      {Data: []byte(`db.host: eu-${db.host}`)},
+	}
+
+	t.Run("merge the overlays of the active profiles with the later profiles taking precedence", func(t *testing.T) {
+		withReferences(t)
+		got, overlays, err := LoadProfiles(LoadOptions{FS: app, Application: "conf/application.conf", Profiles: []string{"staging", "prod", "eu"}})
+		assertNoError(t, err)
+		assertDeepEqual(t, overlays, []string{"conf/application.staging.conf", "conf/application.eu.conf"})
+		assertEquals(t, got.String(), `{"db":{"host":"eu-staging.internal", "port":6432}, "name":"app"}`)
+		origin, err := got.Origin("db.port")
+		assertNoError(t, err)
+		assertEquals(t, origin.String(), "conf/application.staging.conf:2:4")
+	})
+
+	t.Run("read the active profiles from the environment variable", func(t *testing.T) {
+		withReferences(t)
+		opts := LoadOptions{
+			FS:          app,
+			Application: "conf/application.conf",
+			ProfilesEnv: "APP_PROFILES",
+			Parse:       ParseOptions{Env: EnvMap{"APP_PROFILES": "eu, staging"}},
+		}
+		got, overlays, err := LoadProfiles(opts)
+		assertNoError(t, err)
+		assertDeepEqual(t, overlays, []string{"conf/application.eu.conf", "conf/application.staging.conf"})
+		assertEquals(t, got.GetStringOrPanic("db.host"), "staging.internal")
+	})
+
+	t.Run("not read the active profiles if the environment is disabled", func(t *testing.T) {
+		withReferences(t)
+		t.Setenv("APP_PROFILES", "staging")
+		opts := LoadOptions{FS: app, Application: "conf/application.conf", ProfilesEnv: "APP_PROFILES", Parse: ParseOptions{DisableEnv: true}}
+		got, overlays, err := LoadProfiles(opts)
+		assertNoError(t, err)
+		assertNil(t, overlays)
+		assertEquals(t, got.GetStringOrPanic("db.host"), "localhost")
+	})
+
+	t.Run("give the overrides precedence over the overlays", func(t *testing.T) {
+		withReferences(t)
+		override, err := ParseString("db.port: 7432")
+		assertNoError(t, err)
+		got, err := Load(LoadOptions{FS: app, Application: "conf/application.conf", Profiles: []string{"staging"}, Overrides: []*Config{override}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 7432)
+	})
+
+	t.Run("return an error if a profile is not a valid name", func(t *testing.T) {
+		withReferences(t)
+		got, overlays, err := LoadProfiles(LoadOptions{FS: app, Profiles: []string{"../secrets"}})
+		assertNil(t, got)
+		assertNil(t, overlays)
+		assertError(t, err, errors.New(`invalid profile: "../secrets"`))
+	})
+}
+
+func TestRegisterReference(t *testing.T) {
+	t.Run("panic if the name is registered twice", func(t *testing.T) {
+		withReferences(t, ClasspathRoot{Name: "db", FS: fstest.MapFS{}})
+		assertPanic(t, func() { RegisterReference("db", fstest.MapFS{}) }, "hocon: RegisterReference called twice for db")
+	})
+
+	t.Run("panic if the file system is nil", func(t *testing.T) {
+		withReferences(t)
+		assertPanic(t, func() { RegisterReference("db", nil) }, "hocon: RegisterReference file system is nil for db")
+	})
+}
@@ -0,0 +1,197 @@
+package hocon
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+var (
+	valueType         = reflect.TypeOf((*Value)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// FromStruct function converts the given struct (or pointer to a struct) into a *Config, the fields are mapped to the
+// config keys with the same `hocon:"key"` tags used by the Unmarshal method, `hocon:"key,omitempty"` skips the fields
+// with zero values. Nested structs and maps become Objects, slices and arrays become Arrays, time.Duration values
+// become Durations and the nil pointers, slices and maps become null
+func FromStruct(v interface{}) (*Config, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot create config from: %T, expected a struct", v)
+	}
+
+	value, err := toValue(rv, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*Object).ToConfig(), nil
+}
+
+// FromMap function converts the given map into a *Config with the same rules of the FromStruct function,
+// the keys of the maps are added to the objects in lexical order
+func FromMap(m map[string]interface{}) (*Config, error) {
+	value, err := toValue(reflect.ValueOf(m), "")
+	if err != nil {
+		return nil, err
+	}
+
+	if value.Type() == NullType {
+		return NewObject().ToConfig(), nil
+	}
+
+	return value.(*Object).ToConfig(), nil
+}
+
+// toValue converts the given Go value into the Value of the configuration tree, path is used in the errors
+func toValue(rv reflect.Value, path string) (Value, error) {
+	if !rv.IsValid() {
+		return null, nil
+	}
+
+	rvType := rv.Type()
+
+	switch {
+	case rvType == configType:
+		if rv.IsNil() {
+			return null, nil
+		}
+
+		return rv.Interface().(*Config).root, nil
+	case rvType.Implements(valueType):
+		if isNilValue(rv) {
+			return null, nil
+		}
+
+		return rv.Interface().(Value), nil
+	case rvType == durationType:
+		return Duration(rv.Int()), nil
+	case rvType.Implements(textMarshalerType) && !isNilValue(rv):
+		text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("cannot marshal value at path: %s, %w", path, err)
+		}
+
+		return String(text), nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return null, nil
+		}
+
+		return toValue(rv.Elem(), path)
+	case reflect.Bool:
+		return Boolean(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt {
+			return nil, fmt.Errorf("cannot marshal value at path: %s, %d overflows int", path, rv.Uint())
+		}
+
+		return Int(rv.Uint()), nil
+	case reflect.Float32:
+		return Float32(rv.Float()), nil
+	case reflect.Float64:
+		return Float64(rv.Float()), nil
+	case reflect.String:
+		return String(rv.String()), nil
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return null, nil
+		}
+
+		array := make(Array, 0, rv.Len())
+
+		for i := 0; i < rv.Len(); i++ {
+			element, err := toValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+
+			array = append(array, element)
+		}
+
+		return array, nil
+	case reflect.Map:
+		if rvType.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("cannot marshal value at path: %s, unsupported map key type: %s", path, rvType.Key())
+		}
+
+		if rv.IsNil() {
+			return null, nil
+		}
+
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+		object := NewObject()
+
+		for _, key := range keys {
+			value, err := toValue(rv.MapIndex(key), joinPath(path, key.String()))
+			if err != nil {
+				return nil, err
+			}
+
+			object.Set(key.String(), value)
+		}
+
+		return object, nil
+	case reflect.Struct:
+		object := NewObject()
+
+		for _, f := range structFields(rvType) {
+			fieldRV, ok := fieldByIndexNoAlloc(rv, f.index)
+			if !ok || (f.omitEmpty && fieldRV.IsZero()) {
+				continue
+			}
+
+			value, err := toValue(fieldRV, joinPath(path, f.name))
+			if err != nil {
+				return nil, err
+			}
+
+			object.Set(f.name, value)
+		}
+
+		return object, nil
+	default:
+		return nil, fmt.Errorf("cannot marshal value at path: %s, unsupported type: %s", path, rvType)
+	}
+}
+
+// fieldByIndexNoAlloc returns the nested field of the given struct, returns false if any of the embedded
+// pointers on the way is nil
+func fieldByIndexNoAlloc(rv reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(x)
+	}
+
+	return rv, true
+}
+
+func isNilValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+
+	return false
+}
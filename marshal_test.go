@@ -0,0 +1,118 @@
+package hocon
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFromStruct(t *testing.T) {
+	t.Run("convert the struct into a config with the tagged keys", func(t *testing.T) {
+		port := 5432
+		settings := struct {
+			Name     string            `hocon:"name"`
+			Ratio    float64           `hocon:"ratio"`
+			Weight   float32           `hocon:"weight"`
+			Enabled  bool              `hocon:"enabled"`
+			Timeout  time.Duration     `hocon:"timeout"`
+			Port     *int              `hocon:"port"`
+			Replica  *testDatabase     `hocon:"replica"`
+			Hosts    []string          `hocon:"hosts"`
+			Limits   map[string]uint   `hocon:"limits"`
+			IP       net.IP            `hocon:"ip"`
+			Raw      Value             `hocon:"raw"`
+			Empty    string            `hocon:"empty,omitempty"`
+			Ignored  string            `hocon:"-"`
+			Labels   map[string]string `hocon:"labels"`
+			Database testDatabase
+		}{
+			Name:     "service",
+			Ratio:    0.5,
+			Weight:   1.5,
+			Enabled:  true,
+			Timeout:  time.Second,
+			Port:     &port,
+			Hosts:    []string{"a", "b"},
+			Limits:   map[string]uint{"read": 10},
+			IP:       net.IPv4(127, 0, 0, 1),
+			Raw:      Array{Int(1)},
+			Ignored:  "ignored",
+			Database: testDatabase{Host: "localhost"},
+		}
+		got, err := FromStruct(&settings)
+		assertNoError(t, err)
+		expected := &Config{objectOf(
+			"name", String("service"),
+			"ratio", Float64(0.5),
+			"weight", Float32(1.5),
+			"enabled", Boolean(true),
+			"timeout", Duration(time.Second),
+			"port", Int(5432),
+			"replica", null,
+			"hosts", Array{String("a"), String("b")},
+			"limits", objectOf("read", Int(10)),
+			"ip", String("127.0.0.1"),
+			"raw", Array{Int(1)},
+			"labels", null,
+			"Database", objectOf(
+				"host", String("localhost"),
+				"port", Int(0),
+				"timeout", Duration(0),
+				"hosts", null,
+			),
+		)}
+		assertDeepEqual(t, got, expected)
+	})
+
+	t.Run("decode the created config back into the same struct", func(t *testing.T) {
+		database := testDatabase{Host: "localhost", Port: 5432, Timeout: time.Minute, Hosts: []string{"a"}}
+		config, err := FromStruct(database)
+		assertNoError(t, err)
+		var got testDatabase
+		err = config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, database)
+	})
+
+	t.Run("return an error if the given value is not a struct", func(t *testing.T) {
+		got, err := FromStruct([]int{1})
+		assertNil(t, got)
+		assertError(t, err, errors.New("cannot create config from: []int, expected a struct"))
+	})
+
+	t.Run("return an error with the path of the unsupported value", func(t *testing.T) {
+		got, err := FromStruct(struct {
+			A []func() `hocon:"a"`
+		}{A: []func(){func() {}}})
+		assertNil(t, got)
+		assertError(t, err, errors.New("cannot marshal value at path: a[0], unsupported type: func()"))
+	})
+}
+
+func TestFromMap(t *testing.T) {
+	t.Run("convert the nested maps into a config", func(t *testing.T) {
+		got, err := FromMap(map[string]interface{}{
+			"a": map[string]interface{}{"b": []interface{}{1, "c", nil}},
+			"d": 2 * time.Hour,
+		})
+		assertNoError(t, err)
+		expected := &Config{objectOf(
+			"a", objectOf("b", Array{Int(1), String("c"), null}),
+			"d", Duration(2*time.Hour),
+		)}
+		assertDeepEqual(t, got, expected)
+	})
+
+	t.Run("return an empty config for a nil map", func(t *testing.T) {
+		got, err := FromMap(nil)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf()})
+	})
+
+	t.Run("return an error if a nested map does not have string keys", func(t *testing.T) {
+		got, err := FromMap(map[string]interface{}{"a": map[int]int{1: 1}})
+		assertNil(t, got)
+		assertError(t, err, errors.New("cannot marshal value at path: a, unsupported map key type: int"))
+	})
+}
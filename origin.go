@@ -0,0 +1,66 @@
+package hocon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Origin describes where a value of the configuration was defined
+type Origin struct {
+	Filename     string   // the file (or the description of the source) that defines the value, empty for the strings
+	Line         int      // the line of the key that the value is assigned to
+	Column       int      // the column of the key that the value is assigned to
+	IncludeChain []string // the sources that included the Filename, starting from the outermost one
+}
+
+// String method returns the description of the Origin, e.g. "nested/y.conf:2:1 (included from x.conf)"
+func (o *Origin) String() string {
+	description := fmt.Sprintf("%s:%d:%d", originName(o.Filename), o.Line, o.Column)
+	if len(o.IncludeChain) == 0 {
+		return description
+	}
+
+	names := make([]string, 0, len(o.IncludeChain))
+	for i := len(o.IncludeChain) - 1; i >= 0; i-- {
+		names = append(names, originName(o.IncludeChain[i]))
+	}
+
+	return fmt.Sprintf("%s (included from %s)", description, strings.Join(names, " <- "))
+}
+
+func originName(filename string) string {
+	if filename == "" {
+		return "string"
+	}
+
+	return filename
+}
+
+// Origin method returns where the value at the given path was defined (the file, the line and the column of the key
+// and the chain of the includes), returns an error if the value is not found or was not created by the parser
+func (c *Config) Origin(path string) (*Origin, error) {
+	object, ok := c.root.(*Object)
+	if !ok {
+		return nil, fmt.Errorf("config value not found at path: %s", path)
+	}
+
+	key := path
+	if i := strings.LastIndex(path, dotToken); i >= 0 {
+		key = path[i+1:]
+
+		if object, ok = object.find(path[:i]).(*Object); !ok {
+			return nil, fmt.Errorf("config value not found at path: %s", path)
+		}
+	}
+
+	if _, ok := object.Get(key); !ok {
+		return nil, fmt.Errorf("config value not found at path: %s", path)
+	}
+
+	origin, ok := object.Origin(key)
+	if !ok {
+		return nil, fmt.Errorf("config value at path: %s has no origin", path)
+	}
+
+	return origin, nil
+}
@@ -0,0 +1,85 @@
+package hocon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConfig_Origin(t *testing.T) {
+	t.Run("return the line and column of the key parsed from a string", func(t *testing.T) {
+		config, err := ParseString("a: 1\nb {\n  c: 2\n}\nd.e: 3")
+		assertNoError(t, err)
+		got, err := config.Origin("b.c")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Origin{Line: 3, Column: 3})
+		got, err = config.Origin("d.e")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Origin{Line: 5, Column: 3})
+	})
+
+	t.Run("return the origin of the last definition of the key", func(t *testing.T) {
+		config, err := ParseString("a: 1\na: 2")
+		assertNoError(t, err)
+		got, err := config.Origin("a")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Origin{Line: 2, Column: 1})
+	})
+
+	t.Run("return the file and the include chain of the included values", func(t *testing.T) {
+		config, err := ParseResource("testdata/x.conf")
+		assertNoError(t, err)
+		got, err := config.Origin("a")
+		assertNoError(t, err)
+		expected := &Origin{
+			Filename:     "testdata/a.conf",
+			Line:         1,
+			Column:       1,
+			IncludeChain: []string{"testdata/x.conf", "testdata/nested/y.conf"},
+		}
+		assertDeepEqual(t, got, expected)
+		got, err = config.Origin("x")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Origin{Filename: "testdata/x.conf", Line: 2, Column: 1})
+	})
+
+	t.Run("keep the origins of both configs merged with fallback", func(t *testing.T) {
+		current, err := ParseString("a { b: 1 }")
+		assertNoError(t, err)
+		fallback, err := ParseString("\na { c: 2 }")
+		assertNoError(t, err)
+		config := current.WithFallback(fallback)
+		got, err := config.Origin("a.b")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Origin{Line: 1, Column: 5})
+		got, err = config.Origin("a.c")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Origin{Line: 2, Column: 5})
+	})
+
+	t.Run("return an error if the value is not found", func(t *testing.T) {
+		config, err := ParseString("a: 1")
+		assertNoError(t, err)
+		got, err := config.Origin("a.b")
+		assertNil(t, got)
+		assertError(t, err, errors.New("config value not found at path: a.b"))
+	})
+
+	t.Run("return an error if the value was not created by the parser", func(t *testing.T) {
+		config, err := FromMap(map[string]interface{}{"a": 1})
+		assertNoError(t, err)
+		got, err := config.Origin("a")
+		assertNil(t, got)
+		assertError(t, err, errors.New("config value at path: a has no origin"))
+	})
+}
+
+func TestOrigin_String(t *testing.T) {
+	t.Run("return the position of a value parsed from a string", func(t *testing.T) {
+		assertEquals(t, (&Origin{Line: 2, Column: 3}).String(), "string:2:3")
+	})
+
+	t.Run("return the file and the includes starting from the closest one", func(t *testing.T) {
+		origin := &Origin{Filename: "a.conf", Line: 1, Column: 1, IncludeChain: []string{"x.conf", "nested/y.conf"}}
+		assertEquals(t, origin.String(), "a.conf:1:1 (included from nested/y.conf <- x.conf)")
+	})
+}
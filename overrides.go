@@ -0,0 +1,93 @@
+package hocon
+
+import (
+	"fmt"
+	"strings"
+	"text/scanner"
+)
+
+// ParseOverrides function parses the given "key=value" overrides (e.g. the ones given on the command line) into
+// a Config to put in front of the fallback chain, the later overrides take precedence over the earlier ones,
+// the values are parsed as hocon values, so the durations, arrays and objects are accepted, and "key += value"
+// appends the value to the array of the fallback chain, the substitutions are resolved with the fallback chain
+func ParseOverrides(overrides []string) (*Config, error) {
+	object := NewObject()
+
+	for _, override := range overrides {
+		parsed, err := parseOverride(override)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeObjects(object, parsed)
+	}
+
+	return object.ToConfig(), nil
+}
+
+// parseOverride parses a single override without resolving it, "key += value" is parsed as
+// "key = ${?key} [value]" like the hocon specification defines it
+func parseOverride(override string) (*Object, error) {
+	p := newParser(strings.NewReader(override))
+
+	for p.advance(); p.scanner.TokenText() != objectStartToken; p.advance() {
+		if p.currentRune == scanner.EOF {
+			return nil, fmt.Errorf("invalid override: %q, expected key=value", override)
+		}
+
+		if !isSeparator(p.scanner.TokenText(), p.scanner.Peek()) {
+			continue
+		}
+
+		if p.scanner.TokenText() == "+" {
+			key := strings.TrimSpace(override[:p.scanner.Offset])
+			value := override[p.scanner.Offset+len("+="):]
+			override = fmt.Sprintf("%s = ${?%s} [%s]", key, key, value)
+		}
+
+		break
+	}
+
+	config, err := newParser(strings.NewReader(override)).parseUnresolved()
+	if err != nil {
+		return nil, fmt.Errorf("invalid override: %w", err)
+	}
+
+	object, ok := config.root.(*Object)
+	if !ok {
+		return nil, fmt.Errorf("invalid override: %q, expected key=value", override)
+	}
+
+	return object, nil
+}
+
+// OverridesFlag is a flag.Value that collects the "key=value" overrides of a repeated flag, e.g.
+//
+//	var overrides hocon.OverridesFlag
+//	flag.Var(&overrides, "D", "overrides the config value, e.g. -D db.port=5433")
+type OverridesFlag []string
+
+// String method returns the collected overrides
+func (f *OverridesFlag) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return strings.Join(*f, ", ")
+}
+
+// Set method validates and collects the given override
+func (f *OverridesFlag) Set(override string) error {
+	if _, err := parseOverride(override); err != nil {
+		return err
+	}
+
+	*f = append(*f, override)
+
+	return nil
+}
+
+// Config method parses the collected overrides with the ParseOverrides function
+func (f OverridesFlag) Config() (*Config, error) {
+	return ParseOverrides(f)
+}
@@ -0,0 +1,76 @@
+package hocon
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestParseOverrides(t *testing.T) {
+	fallback, err := ParseStringUnresolved("db { host: localhost, port: 5432 }\nfeatures: [a]")
+	assertNoError(t, err)
+
+	t.Run("parse the values as hocon values", func(t *testing.T) {
+		got, err := ParseOverrides([]string{"db.port=5433", "timeout: 5 seconds", "hosts = [a, b]", `pool {size: 10}`})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5433)
+		assertEquals(t, got.GetDurationOrPanic("timeout"), 5*time.Second)
+		assertDeepEqual(t, got.GetStringSliceOrPanic("hosts"), []string{"a", "b"})
+		assertEquals(t, got.GetIntOrPanic("pool.size"), 10)
+	})
+
+	t.Run("give the later overrides precedence and merge them in front of the fallback chain", func(t *testing.T) {
+		overrides, err := ParseOverrides([]string{"db.port=5433", "db.port=5434", `db.url = "postgres://"${db.host}":"${db.port}`})
+		assertNoError(t, err)
+		got, err := overrides.WithFallback(fallback).Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5434)
+		assertEquals(t, got.GetStringOrPanic("db.url"), "postgres://localhost:5434")
+	})
+
+	t.Run("append the values to the arrays of the fallback chain", func(t *testing.T) {
+		overrides, err := ParseOverrides([]string{"features += x", "features+={name: y}", "tags += z"})
+		assertNoError(t, err)
+		got, err := overrides.WithFallback(fallback).Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertEquals(t, got.GetArrayOrPanic("features").String(), `["a","x",{"name":"y"}]`)
+		assertDeepEqual(t, got.GetStringSliceOrPanic("tags"), []string{"z"})
+	})
+
+	for override, expected := range map[string]string{
+		"db.port":          `invalid override: "db.port", expected key=value`,
+		"[1, 2]":           `invalid override: "[1, 2]", expected key=value`,
+		"db.port = [1, 2":  "invalid override: invalid config array! at: 1:15, parenthesis do not match",
+		`include "a.conf"`: `invalid override: "include \"a.conf\"", expected key=value`,
+	} {
+		t.Run("return an error for the invalid override "+override, func(t *testing.T) {
+			got, err := ParseOverrides([]string{override})
+			assertNil(t, got)
+			assertError(t, err, errors.New(expected))
+		})
+	}
+}
+
+func TestOverridesFlag(t *testing.T) {
+	t.Run("collect the repeated flags", func(t *testing.T) {
+		var overrides OverridesFlag
+		flags := flag.NewFlagSet("app", flag.ContinueOnError)
+		flags.Var(&overrides, "D", "overrides the config value")
+		assertNoError(t, flags.Parse([]string{"-D", "db.port=5433", "-D", "features += x"}))
+		assertEquals(t, overrides.String(), "db.port=5433, features += x")
+		got, err := overrides.Config()
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("db.port"), 5433)
+	})
+
+	t.Run("reject the invalid overrides", func(t *testing.T) {
+		var overrides OverridesFlag
+		flags := flag.NewFlagSet("app", flag.ContinueOnError)
+		flags.SetOutput(io.Discard)
+		flags.Var(&overrides, "D", "overrides the config value")
+		err := flags.Parse([]string{"-D", "db.port"})
+		assertError(t, err, errors.New(`invalid value "db.port" for flag -D: invalid override: "db.port", expected key=value`))
+	})
+}
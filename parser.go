@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"text/scanner"
@@ -32,24 +34,92 @@ var forbiddenCharacters = map[string]bool{
 	"!": true, "@": true, "*": true, "&": true, `\`: true, "(": true, ")": true,
 }
 
+// IncludePolicy controls how the include statements of the parsed sources are handled
+type IncludePolicy int
+
+// IncludePolicy constants
+const (
+	IncludeAllow IncludePolicy = iota // the included resources are parsed and merged, the default
+	IncludeSkip                       // the include statements are parsed but the resources are not loaded
+	IncludeDeny                       // the include statements are reported as a ParseError
+)
+
+// ParseOptions configures the parsing of the configuration sources
+type ParseOptions struct {
+	// BaseDir is the directory that the includes of the source are resolved from, defaults to the directory of
+	// the parsed resource (or the name given to ParseReader) and to the working directory for the strings
+	BaseDir string
+	// IncludePolicy controls how the include statements are handled, the includes are allowed by default
+	IncludePolicy IncludePolicy
+	// Resolvers resolves the substitutions with a scheme prefix by the scheme, e.g. ${file:/run/secrets/db} is resolved
+	// by the "file" resolver, see the EnvResolver and FileResolver functions for the built-in resolvers
+	Resolvers map[string]Resolver
+	// Env provides the environment variables for the substitutions that are not found in the configuration tree,
+	// e.g. hocon.EnvMap{"HOME": "/home/app"}, the environment of the process is used if it is nil
+	Env Env
+	// DisableEnv disables looking up the substitutions that are not found in the configuration tree in the environment
+	DisableEnv bool
+	// AllowUnresolved keeps the substitutions that cannot be resolved instead of returning an error
+	AllowUnresolved bool
+	// OriginDescription describes the source in the origins of the values instead of the file path or the name
+	OriginDescription string
+	// Includer opens the sources of the include statements, FileIncluder is used if it is nil
+	Includer Includer
+	// IncludeRoot restricts the files included by the FileIncluder to the given directory, the includes outside of it
+	// (with ".." or through the symbolic links) are reported as errors, it is not used if the Includer is set
+	IncludeRoot string
+	// MaxIncludeDepth limits how deep the includes can be nested, e.g. 1 allows the includes of the parsed source
+	// but not the includes of the included sources, the depth is not limited if it is 0
+	MaxIncludeDepth int
+}
+
+// resolveOptions returns the options to resolve the substitutions of the parsed configuration
+func (o ParseOptions) resolveOptions() ResolveOptions {
+	return ResolveOptions{AllowUnresolved: o.AllowUnresolved, Resolvers: o.Resolvers, Env: o.Env, DisableEnv: o.DisableEnv}
+}
+
 type parser struct {
 	scanner                 *scanner.Scanner
 	currentRune             rune
-	lastConsumedWhitespaces string // used in concatenation not to lose whitespaces between values
-	filepath                string
+	lastConsumedWhitespaces string   // used in concatenation not to lose whitespaces between values
+	filepath                string   // empty if the source is not a file
+	baseDir                 string   // the directory that the includes are resolved from
+	description             string   // the name of the source in the origins, empty if the source is a string
+	includeChain            []string // the sources that included the source of the parser, starting from the outermost one
+	sources                 []string // the names of the included sources being parsed, ending with the source of the parser
+	path                    string   // the path of the object being parsed in the configuration tree
+	rootPath                string   // the path that the source is included at, empty for the sources that are not included
+	options                 ParseOptions
 }
 
 func newParser(src io.Reader) *parser {
 	s := newScanner(src)
-	currWd := "."
 
-	return &parser{scanner: s, filepath: currWd}
+	return &parser{scanner: s, baseDir: "."}
 }
 
-func newFileParser(src *os.File) *parser {
-	s := newScanner(src)
+// newParserWithOptions creates a parser of the source with the given name (usually the path of the source),
+// the name is used to resolve the includes and in the origins unless the options override them
+func newParserWithOptions(src io.Reader, name string, opts ParseOptions) *parser {
+	p := newParser(src)
+	p.filepath = name
+	p.baseDir = path.Dir(name)
+	p.description = name
+	p.options = opts
+
+	if name != "" {
+		p.sources = []string{path.Clean(name)}
+	}
+
+	if opts.BaseDir != "" {
+		p.baseDir = opts.BaseDir
+	}
 
-	return &parser{scanner: s, filepath: src.Name()}
+	if opts.OriginDescription != "" {
+		p.description = opts.OriginDescription
+	}
+
+	return p
 }
 
 func newScanner(src io.Reader) *scanner.Scanner {
@@ -67,175 +137,181 @@ func newScanner(src io.Reader) *scanner.Scanner {
 // ParseString function parses the given hocon string, creates the configuration tree and
 // returns a pointer to the Config, returns a ParseError if any error occurs while parsing
 func ParseString(input string) (*Config, error) {
-	parser := newParser(strings.NewReader(input))
-	return parser.parse()
+	return ParseStringWithOptions(input, ParseOptions{})
 }
 
 // ParseResource parses the resource at the given path, creates the configuration tree and
 // returns a pointer to the Config, returns the error if any error occurs while parsing
 func ParseResource(path string) (*Config, error) {
+	return ParseResourceWithOptions(path, ParseOptions{})
+}
+
+// ParseStringWithOptions function parses the given hocon string like the ParseString function with the given options
+func ParseStringWithOptions(input string, opts ParseOptions) (*Config, error) {
+	return ParseReader(strings.NewReader(input), "", opts)
+}
+
+// ParseResourceWithOptions function parses the resource at the given path like the ParseResource function
+// with the given options
+func ParseResourceWithOptions(path string, opts ParseOptions) (*Config, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse resource: %w", err)
 	}
 
-	return newFileParser(file).parse()
-}
-
-func (p *parser) parse() (*Config, error) {
-	p.advance()
+	defer file.Close()
 
-	if p.scanner.TokenText() == arrayStartToken {
-		array, err := p.extractArray()
-		if err != nil {
-			return nil, err
-		}
+	return ParseReader(file, path, opts)
+}
 
-		return &Config{root: array}, nil
-	}
+// ParseFS function parses the file at the given path of the file system (e.g. an embed.FS) like the ParseResource
+// function, the includes are resolved in the same file system
+func ParseFS(fsys fs.FS, name string) (*Config, error) {
+	return ParseFSWithOptions(fsys, name, ParseOptions{})
+}
 
-	object, err := p.extractObject()
+// ParseFSWithOptions function parses the file at the given path of the file system like the ParseFS function
+// with the given options, the includes are resolved in the file system unless the options set another Includer
+func ParseFSWithOptions(fsys fs.FS, name string, opts ParseOptions) (*Config, error) {
+	file, err := fsys.Open(name)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("could not parse resource: %w", err)
 	}
 
-	if token := p.scanner.TokenText(); token != "" {
-		return nil, invalidObjectError("invalid token "+token, p.scanner.Line, p.scanner.Column)
-	}
+	defer file.Close()
 
-	err = resolveSubstitutions(object)
-	if err != nil {
-		return nil, err
+	if opts.Includer == nil {
+		opts.Includer = FSIncluder(fsys)
 	}
 
-	return &Config{root: object}, nil
+	return ParseReader(file, name, opts)
 }
 
-func (p *parser) advance() {
-	p.currentRune = p.scanner.Scan()
+// ParseReader function parses the hocon source read from the given reader with the given options, name is the path
+// of the source (or any other name describing it), the includes are resolved from the directory of the name
+// and the origins of the values refer to the name unless the options override them
+func ParseReader(r io.Reader, name string, opts ParseOptions) (*Config, error) {
+	return newParserWithOptions(r, name, opts).parse()
+}
 
-	var builder strings.Builder
+// ParseStringUnresolved function parses the given hocon string like the ParseString function but does not resolve
+// the substitutions, the returned Config can be merged with the fallback configs and then resolved with the Resolve method
+func ParseStringUnresolved(input string) (*Config, error) {
+	return ParseStringUnresolvedWithOptions(input, ParseOptions{})
+}
 
-	for p.currentRune == '\t' || p.currentRune == ' ' {
-		builder.WriteString(scanner.TokenString(p.currentRune))
-		p.currentRune = p.scanner.Scan()
-	}
+// ParseResourceUnresolved parses the resource at the given path like the ParseResource function but does not resolve
+// the substitutions, the returned Config can be merged with the fallback configs and then resolved with the Resolve method
+func ParseResourceUnresolved(path string) (*Config, error) {
+	return ParseResourceUnresolvedWithOptions(path, ParseOptions{})
+}
 
-	p.lastConsumedWhitespaces = builder.String()
+// ParseStringUnresolvedWithOptions function parses the given hocon string like the ParseStringUnresolved function
+// with the given options
+func ParseStringUnresolvedWithOptions(input string, opts ParseOptions) (*Config, error) {
+	return newParserWithOptions(strings.NewReader(input), "", opts).parseUnresolved()
 }
 
-func resolveSubstitutions(root Object, valueOptional ...Value) error {
-	visitedPaths := make(map[string]bool)
-	return resolveAcyclicSubstitutions(root, visitedPaths, valueOptional...)
+// ParseResourceUnresolvedWithOptions function parses the resource at the given path like the ParseResourceUnresolved
+// function with the given options
+func ParseResourceUnresolvedWithOptions(path string, opts ParseOptions) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse resource: %w", err)
+	}
+
+	defer file.Close()
+
+	return newParserWithOptions(file, path, opts).parseUnresolved()
 }
 
-func resolveAcyclicSubstitutions(root Object, visitedPaths map[string]bool, valueOptional ...Value) error {
-	var value Value
-	if valueOptional == nil {
-		value = root
-	} else {
-		value = valueOptional[0]
+// parseValue parses a single hocon value (including the concatenations like "5 seconds" or "a b")
+// from the given input, used for the values that are not coming from a configuration file like the default tags
+func parseValue(input string) (Value, error) {
+	p := newParser(strings.NewReader(input))
+	p.advance()
+
+	value, err := p.extractValue()
+	if err != nil {
+		return nil, err
 	}
 
-	switch v := value.(type) {
-	case Array:
-		for i, value := range v {
-			err := processSubstitution(root, value, visitedPaths, func(foundValue Value) { v[i] = foundValue })
-			if err != nil {
-				return err
-			}
+	for p.currentRune != scanner.EOF {
+		concatenated, err := p.checkConcatenation(value)
+		if err != nil {
+			return nil, err
 		}
-	case concatenation:
-		for i, value := range v {
-			err := processSubstitution(root, value, visitedPaths, func(foundValue Value) { v[i] = foundValue })
-			if err != nil {
-				return err
-			}
+
+		if concatenated == nil {
+			return nil, invalidValueError("invalid token "+p.scanner.TokenText(), p.scanner.Line, p.scanner.Column)
 		}
-	case Object:
-		for key, value := range v {
-			err := processSubstitution(root, value, visitedPaths, func(foundValue Value) { v[key] = foundValue })
-			if err != nil {
-				return err
-			}
 
-			if concatenationValue, ok := value.(concatenation); ok && concatenationValue.containsObject() {
-				merged := Object{}
+		value = concatenated
+	}
 
-				for _, value := range concatenationValue {
-					object, ok := value.(Object)
-					if !ok {
-						return invalidConcatenationError()
-					}
+	return value, nil
+}
 
-					mergeObjects(merged, object)
-				}
+func (p *parser) parse() (*Config, error) {
+	config, err := p.parseUnresolved()
+	if err != nil {
+		return nil, err
+	}
 
-				root[key] = merged
-			}
+	if object, ok := config.root.(*Object); ok {
+		if err := newResolver(object, p.options.resolveOptions()).resolve(object, ""); err != nil {
+			return nil, err
 		}
-	default:
-		return invalidValueError("substitutions are only allowed in field values and array elements", 0, 0)
 	}
 
-	return nil
+	return config, nil
 }
 
-func processSubstitution(root Object, value Value, visitedPaths map[string]bool, resolveFunc func(value Value)) error {
-	if valueType := value.Type(); valueType == SubstitutionType {
-		processed, err := processSubstitutionType(root, value.(*Substitution), visitedPaths)
+// parseUnresolved parses the source into the configuration tree without resolving the substitutions
+func (p *parser) parseUnresolved() (*Config, error) {
+	p.advance()
+
+	if p.scanner.TokenText() == arrayStartToken {
+		array, err := p.extractArray()
 		if err != nil {
-			return err
-		}
-		resolveFunc(processed)
-		return nil
-	} else if valueType == valueWithAlternativeType {
-		withAlternative := value.(*valueWithAlternative)
-		if withAlternative.alternative != nil {
-			processed, err := processSubstitutionType(root, withAlternative.alternative, visitedPaths)
-			if err != nil {
-				return err
-			}
-			if processed != nil {
-				resolveFunc(processed)
-				return nil
-			}
+			return nil, err
 		}
-		resolveFunc(withAlternative.value)
-		return nil
-	} else if valueType == ObjectType || valueType == ArrayType || valueType == ConcatenationType {
-		return resolveAcyclicSubstitutions(root, visitedPaths, value)
+
+		return &Config{root: array}, nil
 	}
 
-	return nil
-}
+	object, err := p.extractObject()
+	if err != nil {
+		return nil, err
+	}
 
-func processSubstitutionType(root Object, substitution *Substitution, visitedPaths map[string]bool) (Value, error) {
-	if _, ok := visitedPaths[substitution.path]; ok {
-		return nil, errors.New("detected substitution cycle: " + substitution.String())
+	if token := p.scanner.TokenText(); token != "" {
+		return nil, invalidObjectError("invalid token "+token, p.scanner.Line, p.scanner.Column)
 	}
 
-	if foundValue := root.find(substitution.path); foundValue != nil {
-		visitedPaths[substitution.path] = true
+	return &Config{root: object}, nil
+}
 
-		if err := processSubstitution(root, foundValue, visitedPaths, func(v Value) { foundValue = v }); err != nil {
-			return nil, err
-		}
+func (p *parser) advance() {
+	p.currentRune = p.scanner.Scan()
+
+	var builder strings.Builder
 
-		delete(visitedPaths, substitution.path)
-		return foundValue, nil
-	} else if env, ok := os.LookupEnv(substitution.path); ok {
-		return String(env), nil
-	} else if !substitution.optional {
-		return nil, errors.New("could not resolve substitution: " + substitution.String() + " to a value")
+	for p.currentRune == '\t' || p.currentRune == ' ' {
+		builder.WriteString(scanner.TokenString(p.currentRune))
+		p.currentRune = p.scanner.Scan()
 	}
-	return nil, nil
+
+	p.lastConsumedWhitespaces = builder.String()
 }
 
-func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
-	object := Object{}
+func (p *parser) extractObject(isSubObject ...bool) (*Object, error) {
+	object := NewObject()
 	parenthesisBalanced := true
 
+	objectPath := p.path
+	defer func() { p.path = objectPath }()
+
 	if p.scanner.TokenText() == objectStartToken {
 		parenthesisBalanced = false
 
@@ -253,6 +329,8 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 	lastRow := 0
 
 	for tok := p.scanner.Peek(); tok != scanner.EOF; tok = p.scanner.Peek() {
+		p.path = objectPath
+
 		for p.scanner.TokenText() == commentToken {
 			p.consumeComment()
 		}
@@ -277,6 +355,7 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 			break
 		}
 
+		keyLine, keyColumn := p.scanner.Line, p.scanner.Column
 		key := strings.Trim(p.scanner.TokenText(), `"`)
 		if strings.HasPrefix(key, dotToken) && key != dotToken {
 			key = strings.TrimPrefix(key, dotToken)
@@ -290,6 +369,8 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 			return nil, leadingPeriodError(p.scanner.Line, p.scanner.Column)
 		}
 
+		p.path = joinPath(objectPath, key)
+
 		p.advance()
 		text := p.scanner.TokenText()
 
@@ -315,14 +396,14 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 				return nil, err
 			}
 
-			if existingValue, ok := object[key]; ok {
-				if existingValue.Type() == ObjectType {
-					mergeObjects(existingValue.(Object), extractedObject)
-					extractedObject = existingValue.(Object)
+			if existingValue, ok := object.Get(key); ok {
+				if existingObject, ok := existingValue.(*Object); ok {
+					mergeObjects(existingObject, extractedObject)
+					extractedObject = existingObject
 				}
 			}
 
-			object[key] = extractedObject
+			object.Set(key, extractedObject)
 		}
 
 		switch text {
@@ -335,22 +416,32 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 				return nil, err
 			}
 
-			if existingValue, ok := object[key]; ok {
+			for p.scanner.Line == lastRow && p.scanner.TokenText() != "" {
+				concatenated, err := p.checkConcatenation(value)
+				if err != nil {
+					return nil, err
+				}
+
+				if concatenated == nil {
+					break
+				}
+
+				value = concatenated
+			}
+
+			if existingValue, ok := object.Get(key); ok {
 				if existingValue.Type() == ObjectType && value.Type() == ObjectType {
-					mergeObjects(existingValue.(Object), value.(Object))
+					mergeObjects(existingValue.(*Object), value.(*Object))
 					value = existingValue
-				} else if (existingValue.Type() == SubstitutionType && value.Type() == SubstitutionType) ||
-					(existingValue.Type() == ObjectType && value.Type() == SubstitutionType) ||
+				} else if (existingValue.Type() == ObjectType && value.Type() == SubstitutionType) ||
 					(existingValue.Type() == SubstitutionType && value.Type() == ObjectType) {
 					value = concatenation{existingValue, value}
-				} else if existingValue.Type() == valueWithAlternativeType && value.Type() == SubstitutionType {
-					value = &valueWithAlternative{value: existingValue, alternative: value.(*Substitution)}
-				} else if value.Type() == SubstitutionType {
-					value = &valueWithAlternative{value: existingValue, alternative: value.(*Substitution)}
+				} else {
+					value = withPrevious(value, existingValue)
 				}
 			}
 
-			object[key] = value
+			object.Set(key, value)
 		case "+":
 			if p.scanner.Peek() == '=' {
 				p.advance()
@@ -363,6 +454,10 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 			}
 		}
 
+		if _, ok := object.Get(key); ok {
+			object.setOrigin(key, p.origin(keyLine, keyColumn))
+		}
+
 		for currentRow := p.scanner.Line; currentRow == lastRow && p.scanner.TokenText() != ""; currentRow = p.scanner.Line {
 			concatenated, err := p.checkAndConcatenate(object, key)
 			if err != nil {
@@ -413,28 +508,71 @@ func (p *parser) extractObject(isSubObject ...bool) (Object, error) {
 	return object, nil
 }
 
-func mergeObjects(existing Object, new Object) {
-	for key, value := range new {
-		existingValue, ok := existing[key]
+func mergeObjects(existing *Object, new *Object) {
+	for key, value := range new.All() {
+		existingValue, ok := existing.Get(key)
 		if ok && existingValue != nil && existingValue.Type() == ObjectType && value.Type() == ObjectType {
-			existingObj := existingValue.(Object)
-			mergeObjects(existingObj, value.(Object))
+			existingObj := existingValue.(*Object)
+			mergeObjects(existingObj, value.(*Object))
 			value = existingObj
+		} else if ok && existingValue != nil {
+			value = withPrevious(value, existingValue)
+		}
+
+		existing.Set(key, value)
+
+		if origin, ok := new.Origin(key); ok {
+			existing.setOrigin(key, origin)
 		}
+	}
+}
+
+// mergeFallback merges the fallback object into the existing one, the values of the existing object
+// take precedence and the keys that exist only in the fallback object are added after the existing keys
+func mergeFallback(existing *Object, fallback *Object) {
+	for key, value := range fallback.All() {
+		existingValue, ok := existing.Get(key)
+		if !ok {
+			if object, isObject := value.(*Object); isObject {
+				value = object.copy()
+			}
 
-		existing[key] = value
+			existing.Set(key, value)
+
+			if origin, ok := fallback.Origin(key); ok {
+				existing.setOrigin(key, origin)
+			}
+		} else if existingObject, isObject := existingValue.(*Object); isObject && value.Type() == ObjectType {
+			mergeFallback(existingObject, value.(*Object))
+		} else if existingValue != nil {
+			existing.Set(key, withPrevious(existingValue, value))
+		}
 	}
 }
 
-func (p *parser) parsePlusEqualsValue(existingObject Object, key string) error {
-	existingValue, ok := existingObject[key]
+// withPrevious returns the value of a key that is redefined with the given value, if the value contains substitutions
+// the previous value is kept for the substitutions that refer to the key itself and for the undefined alternatives
+func withPrevious(value, previous Value) Value {
+	if value == nil || isResolved(value) {
+		return value
+	}
+
+	if withAlternative, ok := value.(*valueWithAlternative); ok {
+		return &valueWithAlternative{value: withPrevious(withAlternative.value, previous), alternative: withAlternative.alternative}
+	}
+
+	return &valueWithAlternative{value: previous, alternative: value}
+}
+
+func (p *parser) parsePlusEqualsValue(existingObject *Object, key string) error {
+	existingValue, ok := existingObject.Get(key)
 	if !ok {
 		value, err := p.extractValue()
 		if err != nil {
 			return err
 		}
 
-		existingObject[key] = Array{value}
+		existingObject.Set(key, Array{value})
 	} else {
 		if existingValue.Type() != ArrayType {
 			return invalidValueError(fmt.Sprintf("value: %q of the key: %q is not an array", existingValue.String(), key), p.scanner.Line, p.scanner.Pos().Column)
@@ -443,7 +581,7 @@ func (p *parser) parsePlusEqualsValue(existingObject Object, key string) error {
 		if err != nil {
 			return err
 		}
-		existingObject[key] = append(existingValue.(Array), value)
+		existingObject.Set(key, append(existingValue.(Array), value))
 	}
 
 	return nil
@@ -466,7 +604,11 @@ func (p *parser) validateIncludeValue() (*include, error) {
 		token = p.scanner.TokenText()
 	}
 
-	if token == "file" || token == "classpath" {
+	kind := BareInclude
+
+	if includeKind, ok := includeKinds[token]; ok {
+		kind = includeKind
+
 		p.advance()
 
 		if p.scanner.TokenText() != "(" {
@@ -494,37 +636,126 @@ func (p *parser) validateIncludeValue() (*include, error) {
 
 	tokenLength := len(token)
 	if !strings.HasPrefix(token, `"`) || !strings.HasSuffix(token, `"`) || tokenLength < 2 {
-		return nil, invalidValueError("expected quoted string, optionally wrapped in 'file(...)' or 'classpath(...)'", p.scanner.Line, p.scanner.Column)
+		return nil, invalidValueError("expected quoted string, optionally wrapped in 'file(...)', 'classpath(...)', 'url(...)' or 'glob(...)'", p.scanner.Line, p.scanner.Column)
 	}
 
-	return &include{path: token[1 : tokenLength-1], required: required}, nil // remove double quotes
+	return &include{path: token[1 : tokenLength-1], required: required, kind: kind}, nil // remove double quotes
 }
 
-func (p *parser) parseIncludedResource() (includeObject Object, err error) {
+func (p *parser) parseIncludedResource() (includeObject *Object, err error) {
 	includeToken, err := p.validateIncludeValue()
 	if err != nil {
 		return nil, err
 	}
 
-	parsedFileParentDir := path.Dir(p.filepath)
-	includePath := path.Join(parsedFileParentDir, includeToken.path)
-	file, err := os.Open(includePath)
+	switch p.options.IncludePolicy {
+	case IncludeSkip:
+		return NewObject(), nil
+	case IncludeDeny:
+		return nil, invalidIncludeError("includes are not allowed", p.scanner.Line, p.scanner.Column)
+	}
+
+	if maxDepth := p.options.MaxIncludeDepth; maxDepth > 0 && len(p.includeChain) >= maxDepth {
+		message := fmt.Sprintf("maximum include depth of %d is exceeded", maxDepth)
+		return nil, invalidIncludeError(message, p.scanner.Line, p.scanner.Column)
+	}
+
+	sources, err := p.includeSources(Include{
+		Kind:     includeToken.kind,
+		Location: includeToken.path,
+		Required: includeToken.required,
+		Parent:   p.filepath,
+		BaseDir:  p.baseDir,
+	})
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) && !includeToken.required {
-			return Object{}, nil
+		if errors.Is(err, fs.ErrNotExist) && !includeToken.required {
+			return NewObject(), nil
 		}
 
 		return nil, fmt.Errorf("could not parse resource: %w", err)
 	}
 
-	includeParser := newFileParser(file)
-
 	defer func() {
-		if closingErr := file.Close(); closingErr != nil {
-			err = closingErr
+		for _, source := range sources {
+			if closingErr := source.Reader.Close(); closingErr != nil && err == nil {
+				err = closingErr
+			}
 		}
 	}()
 
+	for _, source := range sources {
+		if i := slices.Index(p.sources, source.Name); i >= 0 {
+			chain := append(slices.Clone(p.sources[i:]), source.Name)
+			return nil, includeCycleError(chain, p.scanner.Line, p.scanner.Column)
+		}
+	}
+
+	includeObject = NewObject()
+
+	for _, source := range sources {
+		object, err := p.parseIncludeSource(source)
+		if err != nil {
+			return nil, err
+		}
+
+		mergeObjects(includeObject, object)
+	}
+
+	return includeObject, nil
+}
+
+// includeSources opens the sources of the include with the includer, the locations without an extension (except for
+// the url(...) and glob(...) includes) are probed with the .properties, .json and .conf extensions (in the order of
+// merging) and all the existing ones are included
+func (p *parser) includeSources(include Include) ([]IncludeSource, error) {
+	if include.Kind == URLInclude || include.Kind == GlobInclude || slices.Contains(probedExtensions, path.Ext(include.Location)) {
+		return p.includer().Include(include)
+	}
+
+	var sources []IncludeSource
+
+	location := include.Location
+
+	for _, extension := range probedExtensions {
+		include.Location = location + extension
+
+		probed, err := p.includer().Include(include)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			closeSources(sources)
+
+			return nil, err
+		}
+
+		sources = append(sources, probed...)
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("none of %s.conf, %s.json and %s.properties exists: %w", location, location, location, fs.ErrNotExist)
+	}
+
+	return sources, nil
+}
+
+// parseIncludeSource parses the object of the given source included by the current source, the sources with
+// the .json extension are parsed as strict JSON and the sources with the .properties extension as properties
+func (p *parser) parseIncludeSource(source IncludeSource) (*Object, error) {
+	includeParser := newParser(source.Reader)
+	includeParser.filepath = source.Name
+	includeParser.baseDir = path.Dir(source.Name)
+	includeParser.description = source.Name
+	includeParser.includeChain = append(slices.Clone(p.includeChain), p.description)
+	includeParser.sources = append(slices.Clone(p.sources), source.Name)
+	includeParser.options = p.options
+	includeParser.path = p.path
+	includeParser.rootPath = p.path
+
+	switch path.Ext(source.Name) {
+	case jsonExtension:
+		return includeParser.parseJSON(source.Reader)
+	case propertiesExtension:
+		return includeParser.parseProperties(source.Reader)
+	}
+
 	includeParser.advance()
 
 	if includeParser.scanner.TokenText() == arrayStartToken {
@@ -534,8 +765,22 @@ func (p *parser) parseIncludedResource() (includeObject Object, err error) {
 	return includeParser.extractObject()
 }
 
-func (p *parser) checkAndConcatenate(object Object, key string) (bool, error) {
-	if lastValue, ok := object[key]; ok && lastValue.isConcatenable() && p.isTokenConcatenable(p.scanner.TokenText(), p.scanner.Peek()) {
+// includer returns the Includer of the options or the FileIncluder (restricted to the include root) if it is not set
+func (p *parser) includer() Includer {
+	if p.options.Includer != nil {
+		return p.options.Includer
+	}
+
+	return fileIncluder(p.options.IncludeRoot)
+}
+
+// origin returns the origin of a value defined at the given position of the current source
+func (p *parser) origin(line, column int) *Origin {
+	return &Origin{Filename: p.description, Line: line, Column: column, IncludeChain: p.includeChain}
+}
+
+func (p *parser) checkAndConcatenate(object *Object, key string) (bool, error) {
+	if lastValue, ok := object.Get(key); ok && p.canConcatenate(lastValue) {
 		lastConsumedWhitespaces := p.lastConsumedWhitespaces
 
 		value, err := p.extractValue()
@@ -544,9 +789,9 @@ func (p *parser) checkAndConcatenate(object Object, key string) (bool, error) {
 		}
 
 		if lastValue.Type() == ConcatenationType {
-			object[key] = append(lastValue.(concatenation), String(lastConsumedWhitespaces), value)
+			object.Set(key, append(lastValue.(concatenation), String(lastConsumedWhitespaces), value))
 		} else {
-			object[key] = concatenation{lastValue, String(lastConsumedWhitespaces), value}
+			object.Set(key, concatenation{lastValue, String(lastConsumedWhitespaces), value})
 		}
 
 		return true, nil
@@ -556,7 +801,7 @@ func (p *parser) checkAndConcatenate(object Object, key string) (bool, error) {
 }
 
 func (p *parser) checkConcatenation(lastValue Value) (Value, error) {
-	if lastValue.isConcatenable() && p.isTokenConcatenable(p.scanner.TokenText(), p.scanner.Peek()) {
+	if p.canConcatenate(lastValue) {
 		lastConsumedWhitespaces := p.lastConsumedWhitespaces
 
 		value, err := p.extractValue()
@@ -579,6 +824,11 @@ func (p *parser) extractArray() (Array, error) {
 		return nil, invalidArrayError(fmt.Sprintf("%q is not an array start token", firstToken), p.scanner.Line, p.scanner.Column)
 	}
 
+	arrayPath := p.path
+	p.path = "" // the elements cannot be referred by the paths, so the includes in them are not prefixed
+
+	defer func() { p.path = arrayPath }()
+
 	p.advance()
 
 	token := p.scanner.TokenText()
@@ -772,6 +1022,8 @@ func (p *parser) extractDurationUnit() time.Duration {
 }
 
 func (p *parser) extractSubstitution() (*Substitution, error) {
+	origin := p.origin(p.scanner.Line, p.scanner.Column)
+
 	p.advance() // skip "$"
 	p.advance() // skip "{"
 
@@ -782,6 +1034,10 @@ func (p *parser) extractSubstitution() (*Substitution, error) {
 		p.advance()
 	}
 
+	if p.currentRune == scanner.Ident && p.scanner.Peek() == ':' {
+		return p.extractSchemeSubstitution(optional, origin)
+	}
+
 	token := p.scanner.TokenText()
 	if token == objectEndToken {
 		return nil, invalidSubstitutionError("path expression cannot be empty", p.scanner.Line, p.scanner.Column)
@@ -833,7 +1089,76 @@ func (p *parser) extractSubstitution() (*Substitution, error) {
 		return nil, invalidSubstitutionError("missing closing parenthesis", p.scanner.Line, p.scanner.Column)
 	}
 
-	return &Substitution{path: pathBuilder.String(), optional: optional}, nil
+	return &Substitution{path: pathBuilder.String(), optional: optional, origin: origin, prefix: p.rootPath}, nil
+}
+
+// extractSchemeSubstitution extracts the substitutions with a scheme prefix like ${env:HOME} or ${file:/run/secrets/db},
+// the raw characters between the colon and the closing brace are the key that is passed to the resolver of the scheme,
+// the quoted parts of the key are unquoted, e.g. ${vault:"secret/db password"}
+func (p *parser) extractSchemeSubstitution(optional bool, origin *Origin) (*Substitution, error) {
+	scheme := p.scanner.TokenText()
+
+	p.scanner.Next() // skip ":"
+
+	var keyBuilder strings.Builder
+
+	for ch := p.scanner.Next(); ch != '}'; ch = p.scanner.Next() {
+		switch ch {
+		case scanner.EOF, '\n':
+			position := p.scanner.Pos()
+			return nil, invalidSubstitutionError("missing closing parenthesis", position.Line, position.Column)
+		case '"':
+			quoted, err := p.extractQuotedKey()
+			if err != nil {
+				return nil, err
+			}
+
+			keyBuilder.WriteString(quoted)
+		default:
+			keyBuilder.WriteRune(ch)
+		}
+	}
+
+	if keyBuilder.Len() == 0 {
+		position := p.scanner.Pos()
+		return nil, invalidSubstitutionError("path expression cannot be empty", position.Line, position.Column-1)
+	}
+
+	p.advance() // move to the token after "}"
+
+	return &Substitution{path: scheme + colonToken + keyBuilder.String(), optional: optional, origin: origin}, nil
+}
+
+// extractQuotedKey reads the raw characters of a quoted part of a scheme substitution key after the opening quote
+// and returns it unquoted
+func (p *parser) extractQuotedKey() (string, error) {
+	quoted := []rune{'"'}
+
+	for ch := p.scanner.Next(); ; ch = p.scanner.Next() {
+		if ch == scanner.EOF || ch == '\n' {
+			position := p.scanner.Pos()
+			return "", invalidSubstitutionError("unclosed quoted key", position.Line, position.Column)
+		}
+
+		quoted = append(quoted, ch)
+
+		if ch == '\\' {
+			quoted = append(quoted, p.scanner.Next())
+			continue
+		}
+
+		if ch == '"' {
+			break
+		}
+	}
+
+	unquoted, err := strconv.Unquote(string(quoted))
+	if err != nil {
+		position := p.scanner.Pos()
+		return "", invalidSubstitutionError("invalid quoted key "+string(quoted), position.Line, position.Column)
+	}
+
+	return unquoted, nil
 }
 
 func (p *parser) consumeComment() {
@@ -871,6 +1196,31 @@ func (p *parser) extractMultiLineString() (String, error) {
 	return "", unclosedMultiLineStringError()
 }
 
+// canConcatenate reports whether the current token starts a value that is concatenated to the given value,
+// the arrays and the objects are concatenated only with each other and with the substitutions
+func (p *parser) canConcatenate(lastValue Value) bool {
+	last := lastValue
+	if concatenationValue, ok := lastValue.(concatenation); ok && len(concatenationValue) > 0 {
+		last = concatenationValue[len(concatenationValue)-1]
+	}
+
+	if last == nil {
+		return false
+	}
+
+	text, peeked := p.scanner.TokenText(), p.scanner.Peek()
+	lastType := last.Type()
+
+	switch {
+	case text == arrayStartToken || text == objectStartToken:
+		return lastType == ArrayType || lastType == ObjectType || lastType == SubstitutionType
+	case lastType == ArrayType || lastType == ObjectType:
+		return isSubstitution(text, peeked)
+	}
+
+	return lastValue.isConcatenable() && p.isTokenConcatenable(text, peeked)
+}
+
 func (p *parser) isTokenConcatenable(currentText string, peeked rune) bool {
 	return isSubstitution(currentText, peeked) ||
 		isUnquotedString(currentText) ||
@@ -903,7 +1253,15 @@ func isMultiLineString(token string, peekedToken rune) bool {
 	return token == `""` && peekedToken == '"'
 }
 
+var includeKinds = map[string]IncludeKind{
+	"file":      FileInclude,
+	"classpath": ClasspathInclude,
+	"url":       URLInclude,
+	"glob":      GlobInclude,
+}
+
 type include struct {
 	path     string
 	required bool
+	kind     IncludeKind
 }
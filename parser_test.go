@@ -1,19 +1,25 @@
 package hocon
 
 import (
+	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 )
 
+//go:embed testdata
+var testdataFS embed.FS
+
 func TestParseString(t *testing.T) {
 	t.Run("parse the string and return a pointer to the Config", func(t *testing.T) {
 		got, err := ParseString("{a:1}")
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Int(1)}})
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1))})
 	})
 
 	t.Run("return the error if any error occurs in the parse() method", func(t *testing.T) {
@@ -38,6 +44,113 @@ func TestParseResource(t *testing.T) {
 	})
 }
 
+func TestParseStringWithOptions(t *testing.T) {
+	t.Run("resolve the includes from the base directory", func(t *testing.T) {
+		got, err := ParseStringWithOptions("include \"a.conf\"\nc: 3", ParseOptions{BaseDir: "testdata"})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "c", Int(3))})
+	})
+
+	t.Run("skip the includes if the include policy is IncludeSkip", func(t *testing.T) {
+		got, err := ParseStringWithOptions("include required(\"testdata/a.conf\")\nc: 3", ParseOptions{IncludePolicy: IncludeSkip})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("c", Int(3))})
+	})
+
+	t.Run("return an error for the includes if the include policy is IncludeDeny", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`c: 3, include "testdata/a.conf"`, ParseOptions{IncludePolicy: IncludeDeny})
+		assertNil(t, got)
+		assertError(t, err, invalidIncludeError("includes are not allowed", 1, 15))
+	})
+
+	t.Run("keep the unresolved substitutions if they are allowed", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`a: ${b}, c: ${d}, d: 1`, ParseOptions{AllowUnresolved: true, DisableEnv: true})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", &Substitution{path: "b"}, "c", Int(1), "d", Int(1))})
+	})
+
+	t.Run("describe the source in the origins with the origin description", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "a.conf"`, ParseOptions{BaseDir: "testdata", OriginDescription: "defaults"})
+		assertNoError(t, err)
+		origin, err := got.Origin("a")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "testdata/a.conf", Line: 1, Column: 1, IncludeChain: []string{"defaults"}})
+	})
+}
+
+func TestParseResourceWithOptions(t *testing.T) {
+	t.Run("return error if the resource cannot be opened", func(t *testing.T) {
+		got, err := ParseResourceWithOptions("nonExistPath", ParseOptions{})
+		assertError(t, err, fmt.Errorf("could not parse resource: open nonExistPath: no such file or directory"))
+		assertNil(t, got)
+	})
+
+	t.Run("resolve the includes from the base directory instead of the directory of the resource", func(t *testing.T) {
+		got, err := ParseResourceWithOptions("testdata/nested/y.conf", ParseOptions{BaseDir: "testdata"})
+		assertError(t, err, errors.New("could not parse resource: open a.conf: no such file or directory"))
+		assertNil(t, got)
+	})
+}
+
+func TestParseReader(t *testing.T) {
+	t.Run("resolve the includes from the directory of the name and use the name in the origins", func(t *testing.T) {
+		got, err := ParseReader(strings.NewReader("include \"a.conf\"\nb: 2"), "testdata/app.conf", ParseOptions{})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "b", Int(2))})
+		origin, err := got.Origin("b")
+		assertNoError(t, err)
+		assertDeepEqual(t, origin, &Origin{Filename: "testdata/app.conf", Line: 2, Column: 1})
+	})
+
+	t.Run("return the parse errors", func(t *testing.T) {
+		got, err := ParseReader(strings.NewReader("a: 1 }"), "app.conf", ParseOptions{})
+		assertError(t, err, invalidObjectError("invalid token }", 1, 6))
+		assertNil(t, got)
+	})
+}
+
+func TestParseFS(t *testing.T) {
+	t.Run("parse the embedded file with the nested includes resolved in the embedded file system", func(t *testing.T) {
+		got, err := ParseFS(testdataFS, "testdata/x.conf")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "y", String("foo"), "x", Int(7))})
+		origin, err := got.Origin("a")
+		assertNoError(t, err)
+		expected := &Origin{Filename: "testdata/a.conf", Line: 1, Column: 1, IncludeChain: []string{"testdata/x.conf", "testdata/nested/y.conf"}}
+		assertDeepEqual(t, origin, expected)
+	})
+
+	t.Run("resolve the includes only in the file system", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"app.conf":     {Data: []byte("include \"testdata/a.conf\"\ninclude \"/conf/db.conf\"\nb: 2")},
+			"conf/db.conf": {Data: []byte("db.port: 5432")},
+		}
+		got, err := ParseFS(fsys, "app.conf")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("db", objectOf("port", Int(5432)), "b", Int(2))})
+	})
+
+	t.Run("return an error if a required include does not exist in the file system", func(t *testing.T) {
+		fsys := fstest.MapFS{"app.conf": {Data: []byte(`include required("missing.conf")`)}}
+		got, err := ParseFS(fsys, "app.conf")
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not parse resource: open missing.conf: file does not exist"))
+	})
+
+	t.Run("return an error if the file does not exist", func(t *testing.T) {
+		got, err := ParseFS(fstest.MapFS{}, "app.conf")
+		assertNil(t, got)
+		assertEquals(t, errors.Is(err, fs.ErrNotExist), true)
+	})
+
+	t.Run("use the includer of the options", func(t *testing.T) {
+		fsys := fstest.MapFS{"app.conf": {Data: []byte("include \"a.conf\"\nb: 2")}}
+		got, err := ParseFSWithOptions(fsys, "app.conf", ParseOptions{BaseDir: "testdata", Includer: FileIncluder()})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "b", Int(2))})
+	})
+}
+
 func TestParse(t *testing.T) {
 	t.Run("try to parse as config array if the input starts with '[' and return the error from extractArray if any", func(t *testing.T) {
 		parser := newParser(strings.NewReader("[5"))
@@ -82,7 +195,7 @@ func TestParse(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:42}"))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Int(42)}})
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(42))})
 	})
 
 	// ###############################################################
@@ -91,7 +204,7 @@ func TestParse(t *testing.T) {
 		parser := newParser(strings.NewReader(`{a:"b"}`))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": String("b")}})
+		assertDeepEqual(t, got, &Config{objectOf("a", String("b"))})
 	})
 
 	t.Run("parse simple array", func(t *testing.T) {
@@ -105,35 +218,35 @@ func TestParse(t *testing.T) {
 		parser := newParser(strings.NewReader(`{a: {c: "d"}}`))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Object{"c": String("d")}}})
+		assertDeepEqual(t, got, &Config{objectOf("a", objectOf("c", String("d")))})
 	})
 
 	t.Run("parse with the omitted root braces", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a=1"))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Int(1)}})
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1))})
 	})
 
 	t.Run("parse the path key", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`{a.b:"c"}`))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Object{"b": String("c")}}})
+		assertDeepEqual(t, got, &Config{objectOf("a", objectOf("b", String("c")))})
 	})
 
 	t.Run("parse the path key that contains a hyphen", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`a.b-1: "c"`))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Object{"b-1": String("c")}}})
+		assertDeepEqual(t, got, &Config{objectOf("a", objectOf("b-1", String("c")))})
 	})
 
 	t.Run("parse the nested object with a key containing a hyphen", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`{a: {b-1: "c"}}`))
 		got, err := parser.parse()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, &Config{Object{"a": Object{"b-1": String("c")}}})
+		assertDeepEqual(t, got, &Config{objectOf("a", objectOf("b-1", String("c")))})
 	})
 }
 
@@ -143,7 +256,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance() // move scanner to the first token for the test case
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{})
+		assertDeepEqual(t, got, objectOf())
 	})
 
 	t.Run("extract object with the root braces omitted", func(t *testing.T) {
@@ -151,7 +264,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Int(1)})
+		assertDeepEqual(t, got, objectOf("a", Int(1)))
 	})
 
 	t.Run("extract simple object", func(t *testing.T) {
@@ -159,7 +272,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Int(1)})
+		assertDeepEqual(t, got, objectOf("a", Int(1)))
 	})
 
 	t.Run("extract nested object", func(t *testing.T) {
@@ -167,7 +280,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Object{"b": Int(1)}, "c": Int(2)})
+		assertDeepEqual(t, got, objectOf("a", objectOf("b", Int(1)), "c", Int(2)))
 	})
 
 	t.Run("extract nested object with the value of unquoted string", func(t *testing.T) {
@@ -175,7 +288,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"x": Object{"a": Object{"b": concatenation{Int(10), String(""), String("cc")}}}})
+		assertDeepEqual(t, got, objectOf("x", objectOf("a", objectOf("b", concatenation{Int(10), String(""), String("cc")}))))
 	})
 
 	t.Run("skip the comments inside objects", func(t *testing.T) {
@@ -189,7 +302,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Int(1)})
+		assertDeepEqual(t, got, objectOf("a", Int(1)))
 	})
 
 	t.Run("return the error if any error occurs in parseIncludedResource method", func(t *testing.T) {
@@ -204,7 +317,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("merge the included object with the existing", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`b:2, include "testdata/a.conf"`))
 		parser.advance()
-		expected := Object{"a": Int(1), "b": Int(2)}
+		expected := objectOf("b", Int(2), "a", Int(1))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -217,7 +330,7 @@ func TestExtractObject(t *testing.T) {
 			include "testdata/b.conf"
 		`))
 		parser.advance()
-		expected := Object{"a": Int(1), "b": Int(2), "c": Int(3)}
+		expected := objectOf("c", Int(3), "a", Int(1), "b", Int(2))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -233,7 +346,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Int(1)})
+		assertDeepEqual(t, got, objectOf("a", Int(1)))
 	})
 
 	for forbiddenChar := range forbiddenCharacters {
@@ -306,7 +419,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return merged object if the current value (after equals separator) is object and there is an existing object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a={b:1},a={c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"b": Int(1), "c": Int(2)}}
+		expected := objectOf("a", objectOf("b", Int(1), "c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -315,7 +428,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("override the existing value if the current value (after equals separator) is object and there is an existing non-object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a=1,a={c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"c": Int(2)}}
+		expected := objectOf("a", objectOf("c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -324,7 +437,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("override the existing value if the current value (after equals separator) is not object", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a={b:1},a=2}"))
 		parser.advance()
-		expected := Object{"a": Int(2)}
+		expected := objectOf("a", Int(2))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -342,20 +455,20 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return merged object if the current value (after colon separator) is object and there is an existing object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:{b:1},a:{c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"b": Int(1), "c": Int(2)}}
+		expected := objectOf("a", objectOf("b", Int(1), "c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
 	})
 
-	t.Run("return object containing a concatenation if the current value (after colon separator) is substitution and there is an existing substitution with the same key", func(t *testing.T) {
+	t.Run("return object containing the overriding substitution if the current value (after colon separator) is substitution and there is an existing substitution with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:1,b:2,c:${a},c:${b}}"))
 		parser.advance()
-		expected := Object{
-			"a": Int(1),
-			"b": Int(2),
-			"c": concatenation{&Substitution{path: "a", optional: false}, &Substitution{path: "b", optional: false}},
-		}
+		expected := objectOf(
+			"a", Int(1),
+			"b", Int(2),
+			"c", &valueWithAlternative{value: &Substitution{path: "a", optional: false}, alternative: &Substitution{path: "b", optional: false}},
+		)
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -364,10 +477,10 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return object containing a concatenation if the current value (after colon separator) is substitution and there is an existing object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{b:2,c:{a:1},c:${b}}"))
 		parser.advance()
-		expected := Object{
-			"b": Int(2),
-			"c": concatenation{Object{"a": Int(1)}, &Substitution{path: "b", optional: false}},
-		}
+		expected := objectOf(
+			"b", Int(2),
+			"c", concatenation{objectOf("a", Int(1)), &Substitution{path: "b", optional: false}},
+		)
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -376,10 +489,10 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return object containing a concatenation if the current value (after colon separator) is substitution and there is an existing object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:1,c:${a},c:{b:2}}"))
 		parser.advance()
-		expected := Object{
-			"a": Int(1),
-			"c": concatenation{&Substitution{path: "a", optional: false}, Object{"b": Int(2)}},
-		}
+		expected := objectOf(
+			"a", Int(1),
+			"c", concatenation{&Substitution{path: "a", optional: false}, objectOf("b", Int(2))},
+		)
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -388,12 +501,12 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return valueWithAlternative object if the current value (after colon separator) is substitution and the existing value is neither a substitution nor object", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:1,a:${?b}}"))
 		parser.advance()
-		expected := Object{
-			"a": &valueWithAlternative{
+		expected := objectOf(
+			"a", &valueWithAlternative{
 				value:       Int(1),
 				alternative: &Substitution{path: "b", optional: true},
 			},
-		}
+		)
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -402,7 +515,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("override the existing value if the current value (after colon separator) is object and there is an existing non-object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:1,a:{c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"c": Int(2)}}
+		expected := objectOf("a", objectOf("c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -411,7 +524,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("override the existing value if the current value (after colon separator) is not object", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a:{b:1},a:2}"))
 		parser.advance()
-		expected := Object{"a": Int(2)}
+		expected := objectOf("a", Int(2))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -420,7 +533,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return merged object if the current value (without separator) is object and there is an existing object with the same key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a{b:1},a{c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"b": Int(1), "c": Int(2)}}
+		expected := objectOf("a", objectOf("b", Int(1), "c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -429,7 +542,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return overwritten object if a key is repeated three times, and the first occurrence is not an object", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a=1,a{b:1},a{c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"b": Int(1), "c": Int(2)}}
+		expected := objectOf("a", objectOf("b", Int(1), "c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -438,7 +551,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return overwritten object if a key is repeated three times, and the second occurrence is not an object", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a{b:1},a=1,a{c:2}}"))
 		parser.advance()
-		expected := Object{"a": Object{"c": Int(2)}}
+		expected := objectOf("a", objectOf("c", Int(2)))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -447,7 +560,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("return overwritten object if a key is repeated three times, and the last occurrence is not an object", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a{b:1},a{c:2},a=1}"))
 		parser.advance()
-		expected := Object{"a": Int(1)}
+		expected := objectOf("a", Int(1))
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -465,7 +578,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("extract object with the += separator", func(t *testing.T) {
 		parser := newParser(strings.NewReader("{a+=1}"))
 		parser.advance()
-		expected := Object{"a": Array{Int(1)}}
+		expected := objectOf("a", Array{Int(1)})
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -485,7 +598,7 @@ func TestExtractObject(t *testing.T) {
 		advanceScanner(t, parser, "b")
 		got, err := parser.extractObject(true)
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"b": Int(1)})
+		assertDeepEqual(t, got, objectOf("b", Int(1)))
 	})
 
 	t.Run("return the error if any error occurs while concatenating", func(t *testing.T) {
@@ -507,7 +620,7 @@ func TestExtractObject(t *testing.T) {
 	t.Run("concatenate multiple values if they are concatenable and in the same line", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:bb cc dd"))
 		parser.advance()
-		expected := Object{"a": concatenation{String("bb"), String(" "), String("cc"), String(" "), String("dd")}}
+		expected := objectOf("a", concatenation{String("bb"), String(" "), String("cc"), String(" "), String("dd")})
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertEquals(t, got.String(), expected.String())
@@ -518,7 +631,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"name": String("value")})
+		assertDeepEqual(t, got, objectOf("name", String("value")))
 	})
 
 	t.Run("should parse properly if the comment contains a `'` character (which results golang scanner to append `\n` to the latest token instead of a separate token)", func(t *testing.T) {
@@ -530,7 +643,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"name": String("value")})
+		assertDeepEqual(t, got, objectOf("name", String("value")))
 	})
 
 	t.Run("return missingCommaError if there is no comma or ASCII newline between the object elements", func(t *testing.T) {
@@ -547,7 +660,7 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Int(1), "b": Int(2)})
+		assertDeepEqual(t, got, objectOf("a", Int(1), "b", Int(2)))
 	})
 
 	t.Run("return adjacentCommasError if there are two adjacent commas between the elements of the object", func(t *testing.T) {
@@ -573,24 +686,24 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"uuid": concatenation{String("123e4567"), String(""), String("-e89b-12d3-a456-426614174000")}})
+		assertDeepEqual(t, got, objectOf("uuid", concatenation{String("123e4567"), String(""), String("-e89b-12d3-a456-426614174000")}))
 	})
 
 	t.Run("extract the object that contains an array with substitution and concatenation", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`{x:a, y:b, arr: [${x}"."${y}]}`))
 		parser.advance()
 		got, err := parser.extractObject()
-		expected := Object{
-			"x": String("a"),
-			"y": String("b"),
-			"arr": Array{concatenation{
+		expected := objectOf(
+			"x", String("a"),
+			"y", String("b"),
+			"arr", Array{concatenation{
 				&Substitution{path: "x", optional: false},
 				String(""),
 				String("."),
 				String(""),
 				&Substitution{path: "y", optional: false},
 			}},
-		}
+		)
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
 	})
@@ -600,31 +713,31 @@ func TestExtractObject(t *testing.T) {
 		parser.advance()
 		got, err := parser.extractObject()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Object{"100": Array{Int(1), Int(2)}}})
+		assertDeepEqual(t, got, objectOf("a", objectOf("100", Array{Int(1), Int(2)})))
 	})
 }
 
 func TestMergeObjects(t *testing.T) {
 	t.Run("merge objects", func(t *testing.T) {
-		existing := Object{"b": Int(5)}
-		new := Object{"c": Int(3)}
-		expected := Object{"b": Int(5), "c": Int(3)}
+		existing := objectOf("b", Int(5))
+		new := objectOf("c", Int(3))
+		expected := objectOf("b", Int(5), "c", Int(3))
 		mergeObjects(existing, new)
 		assertDeepEqual(t, existing, expected)
 	})
 
 	t.Run("merge objects recursively if both parameters contain the same key as of type Object", func(t *testing.T) {
-		existing := Object{"b": Object{"e": Int(5)}}
-		new := Object{"b": Object{"f": Int(7)}, "c": Int(3)}
-		expected := Object{"b": Object{"e": Int(5), "f": Int(7)}, "c": Int(3)}
+		existing := objectOf("b", objectOf("e", Int(5)))
+		new := objectOf("b", objectOf("f", Int(7)), "c", Int(3))
+		expected := objectOf("b", objectOf("e", Int(5), "f", Int(7)), "c", Int(3))
 		mergeObjects(existing, new)
 		assertDeepEqual(t, existing, expected)
 	})
 
 	t.Run("merge objects recursively, value from the second parameter should override the first one if any of them are not of type Object", func(t *testing.T) {
-		existing := Object{"b": Object{"e": Int(5)}, "c": Int(3)}
-		new := Object{"b": Int(7)}
-		expected := Object{"b": Int(7), "c": Int(3)}
+		existing := objectOf("b", objectOf("e", Int(5)), "c", Int(3))
+		new := objectOf("b", Int(7))
+		expected := objectOf("b", Int(7), "c", Int(3))
 		mergeObjects(existing, new)
 		assertDeepEqual(t, existing, expected)
 	})
@@ -632,21 +745,18 @@ func TestMergeObjects(t *testing.T) {
 
 func TestResolveSubstitutions(t *testing.T) {
 	t.Run("resolve valid substitution at the root level", func(t *testing.T) {
-		object := Object{"a": Int(5), "b": &Substitution{"a", false}}
-		err := resolveSubstitutions(object)
+		object := objectOf("a", Int(5), "b", &Substitution{path: "a", optional: false})
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(object, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("resolve to the environment variable if substitution path does not exist and an environment variable is set with the substitution path", func(t *testing.T) {
 		testEnv := "TEST_ENV"
-		substitution := &Substitution{testEnv, false}
-		object := Object{"a": Int(5), "b": substitution}
-		err := os.Setenv(testEnv, "test")
-		assertNoError(t, err)
-		err = resolveSubstitutions(object)
-		assertNoError(t, err)
-		err = os.Unsetenv(testEnv)
+		substitution := &Substitution{path: testEnv, optional: false}
+		object := objectOf("a", Int(5), "b", substitution)
+		err := newResolver(object, ResolveOptions{Env: EnvMap{testEnv: "test"}}).resolve(object, "")
 		assertNoError(t, err)
+		assertEquals(t, object.find("b"), String("test"))
 	})
 
 	t.Run("resolve to the environment variable if substitution path does not exist and environment variable is set and default value was provided", func(t *testing.T) {
@@ -654,17 +764,13 @@ func TestResolveSubstitutions(t *testing.T) {
 		testEnvValue := "test"
 		envSubstitution := &Substitution{path: testEnv, optional: false}
 		staticWithEnv := &valueWithAlternative{value: String("static"), alternative: envSubstitution}
-		object := Object{"a": staticWithEnv}
-		err := os.Setenv(testEnv, testEnvValue)
-		assertNoError(t, err)
+		object := objectOf("a", staticWithEnv)
 		expected := String(testEnvValue)
-		err = resolveSubstitutions(object)
-		assertNoError(t, err)
-		err = os.Unsetenv(testEnv)
+		err := newResolver(object, ResolveOptions{Env: EnvMap{testEnv: testEnvValue}}).resolve(object, "")
 		assertNoError(t, err)
 
-		if expected != object["a"] {
-			t.Errorf("expected value: %s from environment variable: %s, got: %s", expected, testEnv, object["a"])
+		if expected != object.find("a") {
+			t.Errorf("expected value: %s from environment variable: %s, got: %s", expected, testEnv, object.find("a"))
 		}
 	})
 
@@ -672,52 +778,51 @@ func TestResolveSubstitutions(t *testing.T) {
 		defaultValue := String("default")
 		envSubstitution := &Substitution{path: "TEST_ENV", optional: true}
 		staticWithEnv := &valueWithAlternative{value: defaultValue, alternative: envSubstitution}
-		object := Object{"a": staticWithEnv}
-		err := resolveSubstitutions(object)
+		object := objectOf("a", staticWithEnv)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(object, "")
 		assertNoError(t, err)
 
-		if defaultValue != object["a"] {
-			t.Errorf("expected default value: %s, got: %s", defaultValue, object["a"])
+		if defaultValue != object.find("a") {
+			t.Errorf("expected default value: %s, got: %s", defaultValue, object.find("a"))
 		}
 	})
 
 	t.Run("resolve transitive substitutions in unordered Object map", func(t *testing.T) {
 		value := Int(5)
-		object := Object{
-			"a": value,
-			"b": &Substitution{path: "a", optional: false},
-			"c": &Substitution{path: "b", optional: false},
-		}
+		object := objectOf(
+			"a", value,
+			"b", &Substitution{path: "a", optional: false},
+			"c", &Substitution{path: "b", optional: false},
+		)
 
 		var err error
 
-		visitedPaths := make(map[string]bool)
-		err = processSubstitution(object, object.find("c"), visitedPaths, func(foundValue Value) { object["c"] = foundValue })
+		resolver := newResolver(object, ResolveOptions{})
+		err = resolver.processSubstitution(object.find("c"), "c", func(foundValue Value) { object.Set("c", foundValue) })
 		assertNoError(t, err)
-		err = processSubstitution(object, object.find("b"), visitedPaths, func(foundValue Value) { object["b"] = foundValue })
+		err = resolver.processSubstitution(object.find("b"), "b", func(foundValue Value) { object.Set("b", foundValue) })
 		assertNoError(t, err)
 
-		if value != object["b"] {
-			t.Errorf("expected default value: %s, got: %s", value, object["b"])
+		if value != object.find("b") {
+			t.Errorf("expected default value: %s, got: %s", value, object.find("b"))
 		}
 
-		if value != object["c"] {
-			t.Errorf("expected default value: %s, got: %s", value, object["c"])
+		if value != object.find("c") {
+			t.Errorf("expected default value: %s, got: %s", value, object.find("c"))
 		}
 	})
 
 	t.Run("return an error if substitution cycle detected", func(t *testing.T) {
-		object := Object{
-			"a": &Substitution{path: "b", optional: false},
-			"b": &Substitution{path: "c", optional: false},
-			"c": &Substitution{path: "a", optional: false},
-		}
+		object := objectOf(
+			"a", &Substitution{path: "b", optional: false},
+			"b", &Substitution{path: "c", optional: false},
+			"c", &Substitution{path: "a", optional: false},
+		)
 
 		var err error
 
-		visitedPaths := make(map[string]bool)
-		err = processSubstitution(object, object.find("a"), visitedPaths, func(foundValue Value) { object["c"] = foundValue })
-		expectedErr := errors.New("detected substitution cycle: ${b}")
+		err = newResolver(object, ResolveOptions{}).processSubstitution(object.find("a"), "a", func(foundValue Value) { object.Set("a", foundValue) })
+		expectedErr := errors.New("detected substitution cycle: ${a}")
 		assertError(t, err, expectedErr)
 	})
 
@@ -725,112 +830,112 @@ func TestResolveSubstitutions(t *testing.T) {
 		defaultValue := String("default")
 		envSubstitution := &Substitution{path: "TEST_ENV", optional: false}
 		staticWithEnv := &valueWithAlternative{value: defaultValue, alternative: envSubstitution}
-		object := Object{"a": staticWithEnv}
-		err := resolveSubstitutions(object)
+		object := objectOf("a", staticWithEnv)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(object, "")
 
 		expectedErr := errors.New("could not resolve substitution: ${TEST_ENV} to a value")
 		assertError(t, err, expectedErr)
 	})
 
 	t.Run("return an error for non-existing substitution path", func(t *testing.T) {
-		substitution := &Substitution{"c", false}
-		object := Object{"a": Int(5), "b": substitution}
-		err := resolveSubstitutions(object)
+		substitution := &Substitution{path: "c", optional: false}
+		object := objectOf("a", Int(5), "b", substitution)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(object, "")
 		expectedError := errors.New("could not resolve substitution: " + substitution.String() + " to a value")
 		assertError(t, err, expectedError)
 	})
 
 	t.Run("ignore the optional substitution if it's path does not exist", func(t *testing.T) {
-		object := Object{"a": Int(5), "b": &Substitution{"c", true}}
-		err := resolveSubstitutions(object)
+		object := objectOf("a", Int(5), "b", &Substitution{path: "c", optional: true})
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(object, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("resolve valid substitution at the non-root level", func(t *testing.T) {
-		subObject := Object{"c": &Substitution{"a", false}}
-		object := Object{"a": Int(5), "b": subObject}
-		err := resolveSubstitutions(object, subObject)
+		subObject := objectOf("c", &Substitution{path: "a", optional: false})
+		object := objectOf("a", Int(5), "b", subObject)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(subObject, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("return invalid concatenation error if the concatenation contains an object and a different type", func(t *testing.T) {
-		substitution := &Substitution{"a", false}
-		object := Object{"a": Int(5), "b": concatenation{Object{"aa": Int(1)}, substitution}}
-		err := resolveSubstitutions(object)
+		substitution := &Substitution{path: "a", optional: false}
+		object := objectOf("a", Int(5), "b", concatenation{objectOf("aa", Int(1)), substitution})
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(object, "")
 		assertError(t, err, invalidConcatenationError())
 	})
 
 	t.Run("resolve the substitution in concatenation and merge the objects if the concatenation's every element is object", func(t *testing.T) {
-		substitution := &Substitution{"a", false}
-		object := Object{"bb": Int(1)}
-		root := Object{"a": Object{"aa": Int(5)}, "b": concatenation{object, substitution}}
-		expected := Object{"aa": Int(5), "bb": Int(1)}
-		err := resolveSubstitutions(root)
+		substitution := &Substitution{path: "a", optional: false}
+		object := objectOf("bb", Int(1))
+		root := objectOf("a", objectOf("aa", Int(5)), "b", concatenation{object, substitution})
+		expected := objectOf("bb", Int(1), "aa", Int(5))
+		err := newResolver(root, ResolveOptions{DisableEnv: true}).resolve(root, "")
 		got := root.find("b")
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
 	})
 
 	t.Run("resolve valid substitution inside an array", func(t *testing.T) {
-		subArray := Array{&Substitution{"a", false}}
-		object := Object{"a": Int(5), "b": subArray}
-		err := resolveSubstitutions(object, subArray)
+		subArray := Array{&Substitution{path: "a", optional: false}}
+		object := objectOf("a", Int(5), "b", subArray)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(subArray, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("return error for non-existing substitution path inside an array", func(t *testing.T) {
-		substitution := &Substitution{"c", false}
+		substitution := &Substitution{path: "c", optional: false}
 		subArray := Array{substitution}
-		object := Object{"a": Int(5), "b": subArray}
-		err := resolveSubstitutions(object, subArray)
+		object := objectOf("a", Int(5), "b", subArray)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(subArray, "")
 		expectedError := errors.New("could not resolve substitution: " + substitution.String() + " to a value")
 		assertError(t, err, expectedError)
 	})
 
 	t.Run("ignore the optional substitution inside an array if it's path does not exist", func(t *testing.T) {
-		subArray := Array{&Substitution{"a", true}}
-		object := Object{"a": Int(5), "b": subArray}
-		err := resolveSubstitutions(object, subArray)
+		subArray := Array{&Substitution{path: "a", optional: true}}
+		object := objectOf("a", Int(5), "b", subArray)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(subArray, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("resolve valid substitution inside a concatenation", func(t *testing.T) {
-		concatenation := concatenation{&Substitution{"a", false}}
-		object := Object{"a": Int(5), "b": concatenation}
-		err := resolveSubstitutions(object, concatenation)
+		concatenation := concatenation{&Substitution{path: "a", optional: false}}
+		object := objectOf("a", Int(5), "b", concatenation)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(concatenation, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("return error for non-existing substitution path inside an concatenation", func(t *testing.T) {
-		substitution := &Substitution{"c", false}
+		substitution := &Substitution{path: "c", optional: false}
 		concatenation := concatenation{substitution}
-		object := Object{"a": Int(5), "b": concatenation}
-		err := resolveSubstitutions(object, concatenation)
+		object := objectOf("a", Int(5), "b", concatenation)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(concatenation, "")
 		expectedError := errors.New("could not resolve substitution: " + substitution.String() + " to a value")
 		assertError(t, err, expectedError)
 	})
 
 	t.Run("ignore the optional substitution inside an concatenation if it's path does not exist", func(t *testing.T) {
-		concatenation := concatenation{&Substitution{"a", true}}
-		object := Object{"a": Int(5), "b": concatenation}
-		err := resolveSubstitutions(object, concatenation)
+		concatenation := concatenation{&Substitution{path: "a", optional: true}}
+		object := objectOf("a", Int(5), "b", concatenation)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(concatenation, "")
 		assertNoError(t, err)
 	})
 
 	t.Run("return error if subConfig is not an object, array or concatenation", func(t *testing.T) {
 		subInt := Int(42)
-		object := Object{"a": Int(5), "b": subInt}
-		err := resolveSubstitutions(object, subInt)
+		object := objectOf("a", Int(5), "b", subInt)
+		err := newResolver(object, ResolveOptions{DisableEnv: true}).resolve(subInt, "")
 		expectedError := invalidValueError("substitutions are only allowed in field values and array elements", 0, 0)
 		assertError(t, err, expectedError)
 	})
 
 	t.Run("extract valueWithAlternative value with string type", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: stringValue, a:${?b}"))
-		expected := Object{"a": &valueWithAlternative{
+		expected := objectOf("a", &valueWithAlternative{
 			value:       String("stringValue"),
 			alternative: &Substitution{path: "b", optional: true},
-		}}
+		})
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -838,10 +943,10 @@ func TestResolveSubstitutions(t *testing.T) {
 
 	t.Run("extract valueWithAlternative value with number type", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: 1, a:${?b}"))
-		expected := Object{"a": &valueWithAlternative{
+		expected := objectOf("a", &valueWithAlternative{
 			value:       Int(1),
 			alternative: &Substitution{path: "b", optional: true},
-		}}
+		})
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -849,10 +954,10 @@ func TestResolveSubstitutions(t *testing.T) {
 
 	t.Run("extract valueWithAlternative value with duration type", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: 1s, a:${?b}"))
-		expected := Object{"a": &valueWithAlternative{
+		expected := objectOf("a", &valueWithAlternative{
 			value:       Duration(time.Second),
 			alternative: &Substitution{path: "b", optional: true},
-		}}
+		})
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -860,10 +965,10 @@ func TestResolveSubstitutions(t *testing.T) {
 
 	t.Run("extract valueWithAlternative value with boolean type", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: true, a:${?b}"))
-		expected := Object{"a": &valueWithAlternative{
+		expected := objectOf("a", &valueWithAlternative{
 			value:       Boolean(true),
 			alternative: &Substitution{path: "b", optional: true},
-		}}
+		})
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -871,9 +976,9 @@ func TestResolveSubstitutions(t *testing.T) {
 
 	t.Run("extract valueWithAlternative value and overwrite alternatives", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: static, a:${?b}"))
-		expected := Object{
-			"a": &valueWithAlternative{value: String("static"), alternative: &Substitution{path: "b", optional: true}},
-		}
+		expected := objectOf(
+			"a", &valueWithAlternative{value: String("static"), alternative: &Substitution{path: "b", optional: true}},
+		)
 		got, err := parser.extractObject()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -884,8 +989,8 @@ func TestParsePlusEqualsValue(t *testing.T) {
 	t.Run("create an array that contains the value if the existingItems map does not contain a value with the given key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a += 42"))
 		advanceScanner(t, parser, "42")
-		existingItems := Object{}
-		expected := Object{"a": Array{Int(42)}}
+		existingItems := objectOf()
+		expected := objectOf("a", Array{Int(42)})
 		err := parser.parsePlusEqualsValue(existingItems, "a")
 		assertNoError(t, err)
 		assertDeepEqual(t, existingItems, expected)
@@ -894,7 +999,7 @@ func TestParsePlusEqualsValue(t *testing.T) {
 	t.Run("return the error received from extractValue method if any, if the existingItems map does not contain a value with the given key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a += [42"))
 		advanceScanner(t, parser, "[")
-		err := parser.parsePlusEqualsValue(Object{}, "a")
+		err := parser.parsePlusEqualsValue(objectOf(), "a")
 		expectedError := invalidArrayError("parenthesis do not match", 1, 7)
 		assertError(t, err, expectedError)
 	})
@@ -902,7 +1007,7 @@ func TestParsePlusEqualsValue(t *testing.T) {
 	t.Run("return an error if the existingItems map contains non-array value with the given key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: 1, a += 42"))
 		advanceScanner(t, parser, "42")
-		existingItems := Object{"a": Int(1)}
+		existingItems := objectOf("a", Int(1))
 		err := parser.parsePlusEqualsValue(existingItems, "a")
 		expectedError := invalidValueError(fmt.Sprintf("value: %q of the key: %q is not an array", "1", "a"), 1, 14)
 		assertError(t, err, expectedError)
@@ -911,7 +1016,7 @@ func TestParsePlusEqualsValue(t *testing.T) {
 	t.Run("return the error received from extractValue method if any, if the existingItems map contains an array with the given key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: [5], a += {42"))
 		advanceScanner(t, parser, "{")
-		existingItems := Object{"a": Array{Int(5)}}
+		existingItems := objectOf("a", Array{Int(5)})
 		err := parser.parsePlusEqualsValue(existingItems, "a")
 		expectedError := invalidObjectError("parenthesis do not match", 1, 15)
 		assertError(t, err, expectedError)
@@ -920,8 +1025,8 @@ func TestParsePlusEqualsValue(t *testing.T) {
 	t.Run("append the value if the existingItems map contains an array with the given key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a: [5], a += 42"))
 		advanceScanner(t, parser, "42")
-		existingItems := Object{"a": Array{Int(5)}}
-		expected := Object{"a": Array{Int(5), Int(42)}}
+		existingItems := objectOf("a", Array{Int(5)})
+		expected := objectOf("a", Array{Int(5), Int(42)})
 		err := parser.parsePlusEqualsValue(existingItems, "a")
 		assertNoError(t, err)
 		assertDeepEqual(t, existingItems, expected)
@@ -968,7 +1073,7 @@ func TestValidateIncludeValue(t *testing.T) {
 	t.Run("return error if the include value does not start with double quotes", func(t *testing.T) {
 		parser := newParser(strings.NewReader("include abc.conf"))
 		advanceScanner(t, parser, "abc")
-		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)' or 'classpath(...)'", 1, 9)
+		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)', 'classpath(...)', 'url(...)' or 'glob(...)'", 1, 9)
 		got, err := parser.validateIncludeValue()
 		assertError(t, err, expectedError)
 		assertNil(t, got)
@@ -977,7 +1082,7 @@ func TestValidateIncludeValue(t *testing.T) {
 	t.Run("return error if the include value does not end with double quotes", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`include "abc.conf`))
 		advanceScanner(t, parser, `"abc.conf`)
-		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)' or 'classpath(...)'", 1, 9)
+		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)', 'classpath(...)', 'url(...)' or 'glob(...)'", 1, 9)
 		got, err := parser.validateIncludeValue()
 		assertError(t, err, expectedError)
 		assertNil(t, got)
@@ -986,7 +1091,7 @@ func TestValidateIncludeValue(t *testing.T) {
 	t.Run("return error if the include value is just a double quotes", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`include "`))
 		advanceScanner(t, parser, `"`)
-		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)' or 'classpath(...)'", 1, 9)
+		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)', 'classpath(...)', 'url(...)' or 'glob(...)'", 1, 9)
 		got, err := parser.validateIncludeValue()
 		assertError(t, err, expectedError)
 		assertNil(t, got)
@@ -1005,7 +1110,7 @@ func TestValidateIncludeValue(t *testing.T) {
 		parser := newParser(strings.NewReader(`include file("abc.conf")`))
 		advanceScanner(t, parser, "file")
 		got, err := parser.validateIncludeValue()
-		expected := &include{path: "abc.conf", required: false}
+		expected := &include{path: "abc.conf", required: false, kind: FileInclude}
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
 	})
@@ -1013,7 +1118,7 @@ func TestValidateIncludeValue(t *testing.T) {
 	t.Run("return the include token containing the path in classpath(...) with quotes removed and required as 'false'", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`include classpath("abc.conf")`))
 		advanceScanner(t, parser, "classpath")
-		expected := &include{path: "abc.conf", required: false}
+		expected := &include{path: "abc.conf", required: false, kind: ClasspathInclude}
 		got, err := parser.validateIncludeValue()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -1041,7 +1146,7 @@ func TestValidateIncludeValue(t *testing.T) {
 		parser := newParser(strings.NewReader(`include required(file("abc.conf"))`))
 		advanceScanner(t, parser, "required")
 		got, err := parser.validateIncludeValue()
-		expected := &include{path: "abc.conf", required: true}
+		expected := &include{path: "abc.conf", required: true, kind: FileInclude}
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
 	})
@@ -1049,7 +1154,25 @@ func TestValidateIncludeValue(t *testing.T) {
 	t.Run("return the include token containing the path in required(classpath(...)) with quotes removed and required as 'true'", func(t *testing.T) {
 		parser := newParser(strings.NewReader(`include required(classpath("abc.conf"))`))
 		advanceScanner(t, parser, "required")
-		expected := &include{path: "abc.conf", required: true}
+		expected := &include{path: "abc.conf", required: true, kind: ClasspathInclude}
+		got, err := parser.validateIncludeValue()
+		assertNoError(t, err)
+		assertDeepEqual(t, got, expected)
+	})
+
+	t.Run("return the include token containing the pattern in glob(...) with quotes removed", func(t *testing.T) {
+		parser := newParser(strings.NewReader(`include glob("conf.d/*.conf")`))
+		advanceScanner(t, parser, "glob")
+		expected := &include{path: "conf.d/*.conf", required: false, kind: GlobInclude}
+		got, err := parser.validateIncludeValue()
+		assertNoError(t, err)
+		assertDeepEqual(t, got, expected)
+	})
+
+	t.Run("return the include token containing the url in url(...) with quotes removed", func(t *testing.T) {
+		parser := newParser(strings.NewReader(`include url("http://localhost/abc.conf")`))
+		advanceScanner(t, parser, "url")
+		expected := &include{path: "http://localhost/abc.conf", required: false, kind: URLInclude}
 		got, err := parser.validateIncludeValue()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -1060,7 +1183,7 @@ func TestParseIncludedResource(t *testing.T) {
 	t.Run("return the error from the validateIncludeValue method if it returns an error", func(t *testing.T) {
 		parser := newParser(strings.NewReader("include abc.conf"))
 		advanceScanner(t, parser, "abc")
-		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)' or 'classpath(...)'", 1, 9)
+		expectedError := invalidValueError("expected quoted string, optionally wrapped in 'file(...)', 'classpath(...)', 'url(...)' or 'glob(...)'", 1, 9)
 		object, err := parser.parseIncludedResource()
 		assertError(t, err, expectedError)
 		assertNil(t, object)
@@ -1071,7 +1194,7 @@ func TestParseIncludedResource(t *testing.T) {
 		advanceScanner(t, parser, `"nonExistFile.conf"`)
 		got, err := parser.parseIncludedResource()
 		assertNil(t, err)
-		assertDeepEqual(t, got, Object{})
+		assertDeepEqual(t, got, objectOf())
 	})
 
 	t.Run("return an error if the file does not exist but the include token is required", func(t *testing.T) {
@@ -1097,7 +1220,35 @@ func TestParseIncludedResource(t *testing.T) {
 		advanceScanner(t, parser, `"testdata/x.conf"`)
 		got, err := parser.parseIncludedResource()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"a": Int(1), "x": Int(7), "y": String("foo")})
+		assertDeepEqual(t, got, objectOf("a", Int(1), "y", String("foo"), "x", Int(7)))
+	})
+}
+
+func TestIncludeCycles(t *testing.T) {
+	t.Run("return an error naming the cycle of the files including each other", func(t *testing.T) {
+		got, err := ParseResource("testdata/cycle/a.conf")
+		assertNil(t, got)
+		expected := includeCycleError([]string{"testdata/cycle/a.conf", "testdata/cycle/b.conf", "testdata/cycle/a.conf"}, 3, 9)
+		assertError(t, err, expected)
+	})
+
+	t.Run("return an error naming the cycle of the files included from a string", func(t *testing.T) {
+		got, err := ParseString(`include "testdata/cycle/b.conf"`)
+		assertNil(t, got)
+		expected := includeCycleError([]string{"testdata/cycle/b.conf", "testdata/cycle/a.conf", "testdata/cycle/b.conf"}, 2, 9)
+		assertError(t, err, expected)
+	})
+
+	t.Run("return an error if a file includes itself", func(t *testing.T) {
+		got, err := ParseResourceUnresolved("./testdata/cycle/self.conf")
+		assertNil(t, got)
+		assertError(t, err, includeCycleError([]string{"testdata/cycle/self.conf", "testdata/cycle/self.conf"}, 1, 9))
+	})
+
+	t.Run("allow including the same file more than once without a cycle", func(t *testing.T) {
+		got, err := ParseString("include \"testdata/a.conf\"\nb { include \"testdata/a.conf\" }")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "b", objectOf("a", Int(1)))})
 	})
 }
 
@@ -1339,7 +1490,7 @@ func TestExtractValue(t *testing.T) {
 		advanceScanner(t, parser, "{")
 		got, err := parser.extractValue()
 		assertNoError(t, err)
-		assertDeepEqual(t, got, Object{"b": Int(1)})
+		assertDeepEqual(t, got, objectOf("b", Int(1)))
 	})
 
 	t.Run("extract array value", func(t *testing.T) {
@@ -1353,7 +1504,7 @@ func TestExtractValue(t *testing.T) {
 	t.Run("extract substitution value", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:${b}"))
 		advanceScanner(t, parser, "$")
-		expected := &Substitution{"b", false}
+		expected := &Substitution{path: "b", optional: false}
 		got, err := parser.extractValue()
 		assertNoError(t, err)
 		assertDeepEqual(t, got, expected)
@@ -1423,6 +1574,56 @@ func TestExtractDurationUnit(t *testing.T) {
 }
 
 func TestExtractSubstitution(t *testing.T) {
+	t.Run("extract the substitution with a scheme prefix and keep the rest of the path as the key", func(t *testing.T) {
+		parser := newParser(strings.NewReader("a:${?file:/run/secrets/db.password}"))
+		advanceScanner(t, parser, "$")
+		substitution, err := parser.extractSubstitution()
+		assertNoError(t, err)
+		assertDeepEqual(t, substitution, &Substitution{path: "file:/run/secrets/db.password", optional: true})
+		assertDeepEqual(t, substitution.origin, &Origin{Line: 1, Column: 3})
+	})
+
+	for input, expected := range map[string]string{
+		"a:${v:a b}":                "v:a b",
+		`a:${v:"quoted key"}`:       "v:quoted key",
+		`a:${v:secret/"db.pass}"}`:  "v:secret/db.pass}",
+		"a:${v:x//y}":               "v:x//y",
+		"a:${v:http://host:80/a#b}": "v:http://host:80/a#b",
+	} {
+		t.Run("keep the raw characters of the scheme key "+input, func(t *testing.T) {
+			parser := newParser(strings.NewReader(input + "\nb:1"))
+			advanceScanner(t, parser, "$")
+			substitution, err := parser.extractSubstitution()
+			assertNoError(t, err)
+			assertEquals(t, substitution.path, expected)
+			assertEquals(t, parser.scanner.TokenText(), "b")
+		})
+	}
+
+	t.Run("return invalidSubstitutionError if the scheme key is not closed", func(t *testing.T) {
+		parser := newParser(strings.NewReader("a:${v:a\nb:1"))
+		advanceScanner(t, parser, "$")
+		substitution, err := parser.extractSubstitution()
+		assertError(t, err, invalidSubstitutionError("missing closing parenthesis", 2, 1))
+		assertNil(t, substitution)
+	})
+
+	t.Run("return invalidSubstitutionError if the quoted part of the scheme key is not closed", func(t *testing.T) {
+		parser := newParser(strings.NewReader(`a:${v:"a}`))
+		advanceScanner(t, parser, "$")
+		substitution, err := parser.extractSubstitution()
+		assertError(t, err, invalidSubstitutionError("unclosed quoted key", 1, 10))
+		assertNil(t, substitution)
+	})
+
+	t.Run("return invalidSubstitutionError if the key of the scheme is empty", func(t *testing.T) {
+		parser := newParser(strings.NewReader("a:${env:}"))
+		advanceScanner(t, parser, "$")
+		substitution, err := parser.extractSubstitution()
+		assertError(t, err, invalidSubstitutionError("path expression cannot be empty", 1, 9))
+		assertNil(t, substitution)
+	})
+
 	t.Run("return invalidSubstitutionError if the path expression is empty", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:${}"))
 		advanceScanner(t, parser, "$")
@@ -1546,7 +1747,7 @@ func TestExtractSubstitution(t *testing.T) {
 
 	for forbiddenChar := range forbiddenCharacters {
 		t.Run(fmt.Sprintf("return error for the forbidden character: %q", forbiddenChar), func(t *testing.T) {
-			if forbiddenChar != "`" && forbiddenChar != `"` && forbiddenChar != "}" && forbiddenChar != "#" {
+			if forbiddenChar != "`" && forbiddenChar != `"` && forbiddenChar != "}" && forbiddenChar != "#" && forbiddenChar != colonToken {
 				parser := newParser(strings.NewReader(fmt.Sprintf("a:${b%s}", forbiddenChar)))
 				advanceScanner(t, parser, "$")
 				expectedError := invalidKeyError(forbiddenChar, 1, 6)
@@ -1652,7 +1853,7 @@ func TestCheckAndConcatenate(t *testing.T) {
 	t.Run("return false if there isn't any value with the given key", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:aa bb"))
 		advanceScanner(t, parser, "bb")
-		got, err := parser.checkAndConcatenate(Object{"a": String("aa")}, "c")
+		got, err := parser.checkAndConcatenate(objectOf("a", String("aa")), "c")
 		assertNoError(t, err)
 		assertEquals(t, got, false)
 	})
@@ -1660,7 +1861,7 @@ func TestCheckAndConcatenate(t *testing.T) {
 	t.Run("return false if the value with the given is not concatenable", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:1s bb"))
 		advanceScanner(t, parser, "bb")
-		got, err := parser.checkAndConcatenate(Object{"a": Duration(1)}, "a")
+		got, err := parser.checkAndConcatenate(objectOf("a", Duration(1)), "a")
 		assertNoError(t, err)
 		assertEquals(t, got, false)
 	})
@@ -1668,7 +1869,7 @@ func TestCheckAndConcatenate(t *testing.T) {
 	t.Run("return false if the current token is not concatenable", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:abc 5"))
 		advanceScanner(t, parser, "5")
-		got, err := parser.checkAndConcatenate(Object{"a": String("abc")}, "5")
+		got, err := parser.checkAndConcatenate(objectOf("a", String("abc")), "5")
 		assertNoError(t, err)
 		assertEquals(t, got, false)
 	})
@@ -1676,7 +1877,7 @@ func TestCheckAndConcatenate(t *testing.T) {
 	t.Run("return the error if any error occurs in the extractValue method", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:abc ${"))
 		advanceScanner(t, parser, "$")
-		object := Object{"a": String("abc")}
+		object := objectOf("a", String("abc"))
 		got, err := parser.checkAndConcatenate(object, "a")
 		assertError(t, err, invalidSubstitutionError("missing closing parenthesis", 1, 9))
 		assertEquals(t, got, false)
@@ -1686,22 +1887,22 @@ func TestCheckAndConcatenate(t *testing.T) {
 		parser := newParser(strings.NewReader("a:aa bb cc"))
 		advanceScanner(t, parser, "cc")
 		whitespace := parser.lastConsumedWhitespaces
-		object := Object{"a": concatenation{String("aa"), String(whitespace), String("bb")}}
+		object := objectOf("a", concatenation{String("aa"), String(whitespace), String("bb")})
 		got, err := parser.checkAndConcatenate(object, "a")
 		assertNoError(t, err)
 		assertEquals(t, got, true)
-		expected := Object{"a": concatenation{String("aa"), String(whitespace), String("bb"), String(whitespace), String("cc")}}
+		expected := objectOf("a", concatenation{String("aa"), String(whitespace), String("bb"), String(whitespace), String("cc")})
 		assertDeepEqual(t, object, expected)
 	})
 
 	t.Run("create a concatenation with the value and the previous value if the previous one is not a concatenation", func(t *testing.T) {
 		parser := newParser(strings.NewReader("a:aa bb"))
 		advanceScanner(t, parser, "bb")
-		object := Object{"a": String("aa")}
+		object := objectOf("a", String("aa"))
 		got, err := parser.checkAndConcatenate(object, "a")
 		assertNoError(t, err)
 		assertEquals(t, got, true)
-		expected := Object{"a": concatenation{String("aa"), String(" "), String("bb")}}
+		expected := objectOf("a", concatenation{String("aa"), String(" "), String("bb")})
 		assertEquals(t, object.String(), expected.String())
 	})
 }
@@ -1743,3 +1944,23 @@ func TestCheckConcatenation(t *testing.T) {
 		assertEquals(t, got.String(), expected.String())
 	})
 }
+
+func TestParseValue(t *testing.T) {
+	t.Run("parse a single value", func(t *testing.T) {
+		got, err := parseValue("30 seconds")
+		assertNoError(t, err)
+		assertEquals(t, got, Duration(30*time.Second))
+	})
+
+	t.Run("parse the concatenated values", func(t *testing.T) {
+		got, err := parseValue("a b")
+		assertNoError(t, err)
+		assertEquals(t, got.String(), "a b")
+	})
+
+	t.Run("return an error if the input contains an invalid token after the value", func(t *testing.T) {
+		got, err := parseValue("1 ]")
+		assertError(t, err, invalidValueError("invalid token ]", 1, 3))
+		assertNil(t, got)
+	})
+}
@@ -0,0 +1,315 @@
+package hocon
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// RenderFormat is the syntax used by the Render methods
+type RenderFormat int
+
+// RenderFormat constants
+const (
+	JSONFormat RenderFormat = iota
+	HOCONFormat
+)
+
+// RenderOptions controls the output of the Render methods
+type RenderOptions struct {
+	// Format selects between the JSON and the native HOCON syntax
+	Format RenderFormat
+	// UseEquals renders the fields as "key = value" instead of "key: value", used only in the HOCON format
+	UseEquals bool
+	// CollapsePaths renders the objects with a single key as dotted paths like "a.b.c = 1"
+	// instead of "a { b { c = 1 } }", used only in the HOCON format
+	CollapsePaths bool
+	// Indent is the number of spaces used for each nesting level, everything is rendered on a single line if it is zero
+	Indent int
+	// SortKeys renders the keys of the objects in lexical order instead of the order they were defined
+	SortKeys bool
+}
+
+// Render method returns the configuration rendered with the given options
+func (c *Config) Render(opts RenderOptions) string { return c.root.Render(opts) }
+
+// Render method returns the String rendered with the given options
+func (s String) Render(opts RenderOptions) string { return render(s, opts) }
+
+func (s *valueWithAlternative) Render(opts RenderOptions) string { return render(s, opts) }
+
+// Render method returns the Object rendered with the given options, the braces of the object are omitted in the
+// HOCON format as it is rendered as a root object
+func (o *Object) Render(opts RenderOptions) string { return render(o, opts) }
+
+// Render method returns the Array rendered with the given options
+func (a Array) Render(opts RenderOptions) string { return render(a, opts) }
+
+// Render method returns the Int rendered with the given options
+func (i Int) Render(opts RenderOptions) string { return render(i, opts) }
+
+// Render method returns the Float32 rendered with the given options
+func (f Float32) Render(opts RenderOptions) string { return render(f, opts) }
+
+// Render method returns the Float64 rendered with the given options
+func (f Float64) Render(opts RenderOptions) string { return render(f, opts) }
+
+// Render method returns the Boolean rendered with the given options
+func (b Boolean) Render(opts RenderOptions) string { return render(b, opts) }
+
+// Render method returns the Substitution rendered with the given options
+func (s *Substitution) Render(opts RenderOptions) string { return render(s, opts) }
+
+// Render method returns the Null rendered with the given options
+func (n Null) Render(opts RenderOptions) string { return render(n, opts) }
+
+// Render method returns the Duration rendered with the given options, durations are rendered as milliseconds in the
+// JSON format and with the largest unit that represents the duration exactly in the HOCON format, like "90s"
+func (d Duration) Render(opts RenderOptions) string { return render(d, opts) }
+
+func (c concatenation) Render(opts RenderOptions) string { return render(c, opts) }
+
+func render(value Value, opts RenderOptions) string {
+	r := &renderer{opts: opts}
+
+	if object, ok := value.(*Object); ok && opts.Format == HOCONFormat {
+		r.rootObject(object)
+	} else {
+		r.value(value, 0)
+	}
+
+	return r.builder.String()
+}
+
+// renderer writes the values into the builder in the format given with the options
+type renderer struct {
+	opts    RenderOptions
+	builder strings.Builder
+}
+
+func (r *renderer) value(value Value, level int) {
+	switch v := value.(type) {
+	case *Object:
+		r.object(v, level)
+	case Array:
+		r.array(v, level)
+	case Float32:
+		r.builder.WriteString(formatFloat(float64(v), 32))
+	case Float64:
+		r.builder.WriteString(formatFloat(float64(v), 64))
+	case Duration:
+		if r.opts.Format == HOCONFormat {
+			r.builder.WriteString(formatDuration(time.Duration(v)))
+		} else {
+			r.builder.WriteString(v.Json())
+		}
+	case *Substitution:
+		if r.opts.Format == HOCONFormat {
+			r.builder.WriteString(v.String())
+		} else {
+			r.builder.WriteString(v.Json())
+		}
+	case concatenation:
+		if r.opts.Format == HOCONFormat {
+			for _, element := range v {
+				r.value(element, level)
+			}
+		} else {
+			r.builder.WriteString(v.Json())
+		}
+	case *valueWithAlternative:
+		if r.opts.Format == HOCONFormat {
+			r.value(v.value, level)
+		} else {
+			r.builder.WriteString(v.Json())
+		}
+	case nil:
+		r.builder.WriteString(string(null))
+	default:
+		r.builder.WriteString(v.Json())
+	}
+}
+
+func (r *renderer) rootObject(object *Object) {
+	for i, key := range r.keys(object) {
+		if i > 0 {
+			r.separateFields(true)
+		}
+
+		value, _ := object.Get(key)
+		r.field(key, value, 0)
+	}
+}
+
+func (r *renderer) object(object *Object, level int) {
+	if object.Len() == 0 {
+		r.builder.WriteString(objectStartToken + objectEndToken)
+		return
+	}
+
+	r.builder.WriteString(objectStartToken)
+
+	for i, key := range r.keys(object) {
+		if i > 0 {
+			r.separateFields(false)
+		}
+
+		r.newline(level + 1)
+
+		value, _ := object.Get(key)
+
+		if r.opts.Format == HOCONFormat {
+			r.field(key, value, level+1)
+		} else {
+			r.builder.WriteString(jsonMarshal(key))
+			r.builder.WriteString(colonToken)
+			r.space()
+			r.value(value, level+1)
+		}
+	}
+
+	r.newline(level)
+	r.builder.WriteString(objectEndToken)
+}
+
+// field writes a key and its value in the HOCON format
+func (r *renderer) field(key string, value Value, level int) {
+	r.builder.WriteString(renderKey(key))
+
+	if r.opts.CollapsePaths {
+		for object, ok := value.(*Object); ok && object.Len() == 1; object, ok = value.(*Object) {
+			for k, v := range object.All() {
+				r.builder.WriteString(dotToken + renderKey(k))
+				value = v
+			}
+		}
+	}
+
+	if value != nil && value.Type() == ObjectType {
+		r.space()
+	} else if r.opts.UseEquals {
+		r.space()
+		r.builder.WriteString(equalsToken)
+		r.space()
+	} else {
+		r.builder.WriteString(colonToken)
+		r.space()
+	}
+
+	r.value(value, level)
+}
+
+func (r *renderer) array(array Array, level int) {
+	if len(array) == 0 {
+		r.builder.WriteString(arrayStartToken + arrayEndToken)
+		return
+	}
+
+	r.builder.WriteString(arrayStartToken)
+
+	for i, element := range array {
+		if i > 0 {
+			r.builder.WriteString(commaToken)
+		}
+
+		r.newline(level + 1)
+		r.value(element, level+1)
+	}
+
+	r.newline(level)
+	r.builder.WriteString(arrayEndToken)
+}
+
+// separateFields writes the separator between the fields of an object, HOCON fields are separated with newlines
+// if the output is indented (the fields of the nested objects get their newlines from the object method)
+func (r *renderer) separateFields(root bool) {
+	if r.opts.Format == HOCONFormat && r.opts.Indent > 0 {
+		if root {
+			r.builder.WriteString("\n")
+		}
+
+		return
+	}
+
+	r.builder.WriteString(commaToken)
+}
+
+func (r *renderer) newline(level int) {
+	if r.opts.Indent > 0 {
+		r.builder.WriteString("\n")
+		r.builder.WriteString(strings.Repeat(" ", level*r.opts.Indent))
+	}
+}
+
+func (r *renderer) space() {
+	if r.opts.Indent > 0 {
+		r.builder.WriteString(" ")
+	}
+}
+
+func (r *renderer) keys(object *Object) []string {
+	keys := object.Keys()
+
+	if r.opts.SortKeys {
+		sort.Strings(keys)
+	}
+
+	return keys
+}
+
+// renderKey returns the key unquoted if it can be parsed back as the same key, quoted otherwise
+func renderKey(key string) string {
+	if key == "" || key == includeToken {
+		return jsonMarshal(key)
+	}
+
+	startsWithDigit := unicode.IsDigit([]rune(key)[0])
+
+	for _, ch := range key {
+		isDigit := unicode.IsDigit(ch)
+		if (!unicode.IsLetter(ch) && !isDigit && ch != '_' && ch != '-') || (startsWithDigit && !isDigit) {
+			return jsonMarshal(key)
+		}
+	}
+
+	return key
+}
+
+// formatFloat formats the float keeping a decimal point, so the rendered value is parsed back as a float
+func formatFloat(f float64, bitSize int) string {
+	formatted := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if !strings.ContainsAny(formatted, ".eEIN") {
+		formatted += ".0"
+	}
+
+	return formatted
+}
+
+// formatDuration formats the duration with the largest unit that represents it exactly
+func formatDuration(duration time.Duration) string {
+	if duration == 0 {
+		return "0s"
+	}
+
+	units := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"d", 24 * time.Hour},
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+		{"ms", time.Millisecond},
+		{"us", time.Microsecond},
+	}
+
+	for _, unit := range units {
+		if duration%unit.duration == 0 {
+			return strconv.FormatInt(int64(duration/unit.duration), 10) + unit.name
+		}
+	}
+
+	return strconv.FormatInt(int64(duration), 10) + "ns"
+}
@@ -0,0 +1,119 @@
+package hocon
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	config := &Config{objectOf(
+		"a", objectOf("b", objectOf("c", Int(1))),
+		"d", Array{Int(1), Float64(2), objectOf("e", String("x y"))},
+		"f", Duration(90*time.Second),
+		"g", objectOf(),
+		"h", null,
+	)}
+
+	t.Run("render as compact JSON", func(t *testing.T) {
+		got := config.Render(RenderOptions{SortKeys: true})
+		assertEquals(t, got, `{"a":{"b":{"c":1}},"d":[1,2.0,{"e":"x y"}],"f":90000,"g":{},"h":null}`)
+	})
+
+	t.Run("render as indented JSON", func(t *testing.T) {
+		got := config.Render(RenderOptions{Indent: 2, SortKeys: true})
+		expected := `{
+  "a": {
+    "b": {
+      "c": 1
+    }
+  },
+  "d": [
+    1,
+    2.0,
+    {
+      "e": "x y"
+    }
+  ],
+  "f": 90000,
+  "g": {},
+  "h": null
+}`
+		assertEquals(t, got, expected)
+	})
+
+	t.Run("render as compact HOCON without the root braces", func(t *testing.T) {
+		got := config.Render(RenderOptions{Format: HOCONFormat, SortKeys: true})
+		assertEquals(t, got, `a{b{c:1}},d:[1,2.0,{e:"x y"}],f:90s,g{},h:null`)
+	})
+
+	t.Run("render as indented HOCON with equals separator and collapsed paths", func(t *testing.T) {
+		got := config.Render(RenderOptions{Format: HOCONFormat, Indent: 4, SortKeys: true, UseEquals: true, CollapsePaths: true})
+		expected := `a.b.c = 1
+d = [
+    1,
+    2.0,
+    {
+        e = "x y"
+    }
+]
+f = 90s
+g {}
+h = null`
+		assertEquals(t, got, expected)
+	})
+
+	t.Run("parse the rendered HOCON back into the same configuration", func(t *testing.T) {
+		rendered := config.Render(RenderOptions{Format: HOCONFormat, Indent: 2, CollapsePaths: true})
+		got, err := ParseString(rendered)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, config)
+	})
+
+	t.Run("render the unresolved substitutions", func(t *testing.T) {
+		object := objectOf("a", concatenation{&Substitution{path: "b"}, String(" "), String("c")})
+		assertEquals(t, object.Render(RenderOptions{Format: HOCONFormat}), `a:${b}" ""c"`)
+		assertEquals(t, object.Render(RenderOptions{}), `{"a":"${b} c"}`)
+	})
+}
+
+func TestRenderKey(t *testing.T) {
+	var testCases = []struct {
+		key      string
+		expected string
+	}{
+		{"a", "a"},
+		{"a-b_c1", "a-b_c1"},
+		{"100", "100"},
+		{"1a", `"1a"`},
+		{"a.b", `"a.b"`},
+		{"a b", `"a b"`},
+		{"", `""`},
+		{"include", `"include"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			assertEquals(t, renderKey(tc.key), tc.expected)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	var testCases = []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "0s"},
+		{48 * time.Hour, "2d"},
+		{90 * time.Minute, "90m"},
+		{1500 * time.Millisecond, "1500ms"},
+		{time.Microsecond, "1us"},
+		{time.Nanosecond, "1ns"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assertEquals(t, formatDuration(tc.duration), tc.expected)
+		})
+	}
+}
@@ -0,0 +1,393 @@
+package hocon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// ResolveOptions configures the resolution of the substitutions
+type ResolveOptions struct {
+	// AllowUnresolved keeps the substitutions that cannot be resolved in the configuration tree instead of
+	// returning an error, the Config can be resolved again after merging it with the fallback configs
+	AllowUnresolved bool
+	// Resolvers resolves the substitutions with a scheme prefix by the scheme, e.g. ${env:HOME} is resolved by the
+	// "env" resolver, the substitutions with a scheme are not looked up in the configuration tree
+	Resolvers map[string]Resolver
+	// Env provides the environment variables for the substitutions that are not found in the configuration tree,
+	// the environment of the process is used if it is nil
+	Env Env
+	// DisableEnv disables looking up the substitutions that are not found in the configuration tree in the environment
+	DisableEnv bool
+}
+
+// lookupEnv looks up the environment variable of the given key in the environment of the options
+func (o ResolveOptions) lookupEnv(key string) (string, bool) {
+	switch {
+	case o.DisableEnv:
+		return "", false
+	case o.Env != nil:
+		return o.Env.LookupEnv(key)
+	}
+
+	return os.LookupEnv(key)
+}
+
+// Resolve method returns a new *Config with all the substitutions resolved against the whole configuration tree,
+// the current Config is not modified, so an unresolved Config can be merged with the fallback configs first
+// (with the WithFallback method) and resolved once at the end
+func (c *Config) Resolve(opts ResolveOptions) (*Config, error) {
+	object, ok := c.root.(*Object)
+	if !ok {
+		return c, nil
+	}
+
+	root := object.copy()
+	if err := newResolver(root, opts).resolve(root, ""); err != nil {
+		return nil, err
+	}
+
+	return root.ToConfig(), nil
+}
+
+// IsResolved method reports whether the configuration tree does not contain any substitutions
+func (c *Config) IsResolved() bool {
+	return isResolved(c.root)
+}
+
+func isResolved(value Value) bool {
+	switch v := value.(type) {
+	case *Substitution, *valueWithAlternative:
+		return false
+	case *Object:
+		for _, value := range v.All() {
+			if !isResolved(value) {
+				return false
+			}
+		}
+	case Array:
+		for _, value := range v {
+			if !isResolved(value) {
+				return false
+			}
+		}
+	case concatenation:
+		for _, value := range v {
+			if value != nil && (value.Type() == ArrayType || value.Type() == ObjectType || !isResolved(value)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// resolver resolves the substitutions of the configuration tree, paths are looked up from the root object
+type resolver struct {
+	root     *Object
+	options  ResolveOptions
+	paths    []string         // the paths of the values being resolved, the last one is the innermost value
+	previous map[string]Value // the previous values of the keys that are redefined with substitutions
+}
+
+func newResolver(root *Object, options ResolveOptions) *resolver {
+	return &resolver{root: root, options: options, previous: make(map[string]Value)}
+}
+
+// resolve resolves the substitutions of the given object, array or concatenation at the given path in place
+func (r *resolver) resolve(value Value, path string) error {
+	switch v := value.(type) {
+	case Array:
+		for i, value := range v {
+			err := r.processSubstitution(value, path, func(foundValue Value) { v[i] = foundValue })
+			if err != nil {
+				return err
+			}
+		}
+	case concatenation:
+		for i, value := range v {
+			err := r.processSubstitution(value, path, func(foundValue Value) { v[i] = foundValue })
+			if err != nil {
+				return err
+			}
+		}
+	case *Object:
+		for _, key := range v.Keys() { // iterates over a copy of the keys as the undefined ones are deleted
+			value, _ := v.Get(key)
+
+			err := r.processSubstitution(value, joinPath(path, key), func(foundValue Value) {
+				if foundValue == nil { // the field of an undefined optional substitution is not created
+					v.Delete(key)
+					return
+				}
+
+				v.Set(key, foundValue)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return invalidValueError("substitutions are only allowed in field values and array elements", 0, 0)
+	}
+
+	return nil
+}
+
+// processSubstitution resolves the given value at the given path and passes the result to the resolveFunc,
+// the result is nil if the value turns out to be undefined
+func (r *resolver) processSubstitution(value Value, path string, resolveFunc func(value Value)) error {
+	if value == nil {
+		return nil
+	}
+
+	r.paths = append(r.paths, path)
+	defer func() { r.paths = r.paths[:len(r.paths)-1] }()
+
+	switch valueType := value.Type(); valueType {
+	case SubstitutionType:
+		processed, err := r.processSubstitutionType(value.(*Substitution))
+		if err != nil {
+			return err
+		}
+		resolveFunc(processed)
+	case valueWithAlternativeType:
+		withAlternative := value.(*valueWithAlternative)
+
+		var processed Value
+
+		err := r.withPrevious(path, withAlternative.value, func() error {
+			return r.processSubstitution(withAlternative.alternative, path, func(v Value) { processed = v })
+		})
+		if err != nil {
+			return err
+		}
+
+		if processed == nil {
+			return r.processSubstitution(withAlternative.value, path, resolveFunc)
+		}
+
+		if !isResolved(processed) { // kept unresolved
+			return nil
+		}
+
+		resolveFunc(processed)
+	case ConcatenationType:
+		if err := r.resolve(value, path); err != nil {
+			return err
+		}
+
+		joined, err := joinConcatenation(value.(concatenation))
+		if err != nil {
+			return err
+		}
+		resolveFunc(joined)
+	case ObjectType, ArrayType:
+		if err := r.resolve(value, path); err != nil {
+			return err
+		}
+		resolveFunc(value)
+	default:
+		resolveFunc(value)
+	}
+
+	return nil
+}
+
+// processSubstitutionType returns the value of the given substitution, nil if an optional substitution cannot be
+// resolved and the substitution itself if it cannot be resolved and the unresolved substitutions are allowed,
+// the substitutions of the included sources are looked up relative to the path of the include first
+func (r *resolver) processSubstitutionType(substitution *Substitution) (Value, error) {
+	path := substitution.path
+
+	if scheme, key, ok := strings.Cut(path, colonToken); ok {
+		return r.processSchemeSubstitution(substitution, scheme, key)
+	}
+
+	if substitution.prefix != "" {
+		value, ok, err := r.lookup(substitution, joinPath(substitution.prefix, path))
+		if err != nil || ok {
+			return value, err
+		}
+	}
+
+	value, ok, err := r.lookup(substitution, path)
+	if err != nil || ok {
+		return value, err
+	}
+
+	return r.processExternalSubstitution(substitution)
+}
+
+// lookup resolves the value at the given path of the configuration tree, reports false if it is not found,
+// a substitution that refers to the value being resolved is resolved to the previous value of it
+func (r *resolver) lookup(substitution *Substitution, path string) (Value, bool, error) {
+	if path == r.paths[len(r.paths)-1] {
+		previous, ok := r.previous[path]
+		if !ok {
+			return nil, false, nil
+		}
+
+		var processed Value
+
+		err := r.withoutPrevious(path, func() error {
+			return r.processSubstitution(previous, path, func(v Value) { processed = v })
+		})
+
+		return processed, true, err
+	}
+
+	if slices.Contains(r.paths, path) {
+		return nil, false, errors.New("detected substitution cycle: " + substitution.String())
+	}
+
+	foundValue := r.root.find(path)
+	if foundValue == nil {
+		return nil, false, nil
+	}
+
+	err := r.processSubstitution(foundValue, path, func(v Value) { foundValue = v })
+
+	return foundValue, true, err
+}
+
+// processExternalSubstitution resolves the substitution that is not found in the configuration tree
+func (r *resolver) processExternalSubstitution(substitution *Substitution) (Value, error) {
+	if env, ok := r.options.lookupEnv(substitution.path); ok {
+		return String(env), nil
+	}
+
+	return r.processMissingSubstitution(substitution)
+}
+
+// processMissingSubstitution returns nil for the optional substitutions and the substitution itself if the unresolved
+// substitutions are allowed, otherwise returns an error
+func (r *resolver) processMissingSubstitution(substitution *Substitution) (Value, error) {
+	if substitution.optional {
+		return nil, nil
+	}
+
+	if r.options.AllowUnresolved {
+		return substitution, nil
+	}
+
+	return nil, errors.New("could not resolve substitution: " + substitution.String() + " to a value")
+}
+
+// processSchemeSubstitution resolves the substitution with a scheme prefix by the resolver of the scheme
+func (r *resolver) processSchemeSubstitution(substitution *Substitution, scheme, key string) (Value, error) {
+	schemeResolver, ok := r.options.Resolvers[scheme]
+	if !ok {
+		return nil, substitutionError(substitution, fmt.Errorf("no resolver for the scheme: %q", scheme))
+	}
+
+	if _, isEnv := schemeResolver.(envResolver); isEnv { // looks up the environment of the options instead of the process
+		schemeResolver = ResolverFunc(func(key string) (Value, bool, error) { return lookupEnvValue(r.options, key) })
+	}
+
+	value, ok, err := schemeResolver.Resolve(key)
+	if err != nil {
+		return nil, substitutionError(substitution, err)
+	}
+
+	if ok {
+		return value, nil
+	}
+
+	return r.processMissingSubstitution(substitution)
+}
+
+// withPrevious calls the given function while the previous value of the given path is set to the given value
+func (r *resolver) withPrevious(path string, previous Value, fn func() error) error {
+	existing, ok := r.previous[path]
+	r.previous[path] = previous
+
+	defer func() {
+		if ok {
+			r.previous[path] = existing
+		} else {
+			delete(r.previous, path)
+		}
+	}()
+
+	return fn()
+}
+
+// withoutPrevious calls the given function while the given path does not have a previous value
+func (r *resolver) withoutPrevious(path string, fn func() error) error {
+	existing := r.previous[path]
+	delete(r.previous, path)
+
+	defer func() { r.previous[path] = existing }()
+
+	return fn()
+}
+
+// joinConcatenation joins the resolved elements of the given concatenation, the arrays are appended and the objects
+// are merged ignoring the whitespaces between them, the undefined optional substitutions are left out
+func joinConcatenation(c concatenation) (Value, error) {
+	var values concatenation
+
+	containsArray := false
+
+	for _, value := range c {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case concatenation:
+			for _, element := range v {
+				if element != nil {
+					values = append(values, element)
+				}
+			}
+		case Array:
+			containsArray = true
+			values = append(values, v)
+		default:
+			values = append(values, v)
+		}
+	}
+
+	switch {
+	case len(values) == 0:
+		return nil, nil
+	case len(values) == 1:
+		return values[0], nil
+	case containsArray && values.containsObject():
+		return nil, invalidConcatenationError()
+	case containsArray:
+		var joined Array
+
+		for _, value := range values {
+			if array, ok := value.(Array); ok {
+				joined = append(joined, array...)
+			} else if !isWhitespace(value) {
+				return nil, invalidConcatenationError()
+			}
+		}
+
+		return joined, nil
+	case values.containsObject():
+		joined := NewObject()
+
+		for _, value := range values {
+			if object, ok := value.(*Object); ok {
+				mergeObjects(joined, object.copy())
+			} else if !isWhitespace(value) {
+				return nil, invalidConcatenationError()
+			}
+		}
+
+		return joined, nil
+	}
+
+	return values, nil
+}
+
+// isWhitespace reports whether the value is the whitespace between the concatenated values (kept in quotes)
+func isWhitespace(value Value) bool {
+	s, ok := value.(String)
+	return ok && strings.TrimSpace(strings.Trim(string(s), `"`)) == ""
+}
@@ -0,0 +1,317 @@
+package hocon
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseStringUnresolved(t *testing.T) {
+	t.Run("keep the substitutions in the configuration tree", func(t *testing.T) {
+		got, err := ParseStringUnresolved("a: ${b}, c: 1")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", &Substitution{path: "b"}, "c", Int(1))})
+		assertEquals(t, got.IsResolved(), false)
+	})
+
+	t.Run("return the parse errors", func(t *testing.T) {
+		got, err := ParseStringUnresolved("a: 1 b: 2")
+		assertNil(t, got)
+		assertError(t, err, missingCommaError(1, 7))
+	})
+
+	t.Run("resolve the includes with the given options", func(t *testing.T) {
+		got, err := ParseStringUnresolvedWithOptions("include \"a.conf\"\nb: ${a}", ParseOptions{BaseDir: "testdata"})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "b", &Substitution{path: "a"})})
+	})
+}
+
+func TestParseResourceUnresolved(t *testing.T) {
+	t.Run("parse the included resources without resolving the substitutions", func(t *testing.T) {
+		got, err := ParseResourceUnresolved("testdata/x.conf")
+		assertNoError(t, err)
+		assertDeepEqual(t, got, &Config{objectOf("a", Int(1), "y", String("foo"), "x", Int(7))})
+	})
+
+	t.Run("return an error if the resource does not exist", func(t *testing.T) {
+		got, err := ParseResourceUnresolved("testdata/missing.conf")
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not parse resource: open testdata/missing.conf: no such file or directory"))
+	})
+
+	t.Run("resolve the includes with the given options", func(t *testing.T) {
+		got, err := ParseResourceUnresolvedWithOptions("testdata/x.conf", ParseOptions{IncludeRoot: "testdata/nested"})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not parse resource: testdata/a.conf is outside of the include root: testdata/nested"))
+	})
+}
+
+func TestConfig_Resolve(t *testing.T) {
+	t.Run("resolve the substitutions against the values of the fallback config", func(t *testing.T) {
+		application, err := ParseStringUnresolved(`db { url: "jdbc:"${db.host}, hosts: [${db.host}] }`)
+		assertNoError(t, err)
+		reference, err := ParseStringUnresolved(`db.host: localhost`)
+		assertNoError(t, err)
+		got, err := application.WithFallback(reference).Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("db.url"), "jdbc:localhost")
+		assertDeepEqual(t, got.GetArrayOrPanic("db.hosts"), Array{String("localhost")})
+		assertEquals(t, got.IsResolved(), true)
+	})
+
+	t.Run("not modify the unresolved config", func(t *testing.T) {
+		config, err := ParseStringUnresolved("a: [${b}], b: 1")
+		assertNoError(t, err)
+		_, err = config.Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertDeepEqual(t, config, &Config{objectOf("a", Array{&Substitution{path: "b"}}, "b", Int(1))})
+	})
+
+	t.Run("return an error if a substitution cannot be resolved", func(t *testing.T) {
+		config, err := ParseStringUnresolved("a: ${b}")
+		assertNoError(t, err)
+		got, err := config.Resolve(ResolveOptions{})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not resolve substitution: ${b} to a value"))
+	})
+
+	t.Run("keep the unresolved substitutions if they are allowed", func(t *testing.T) {
+		config, err := ParseStringUnresolved("a: ${b}, c: ${d}, d: 1, e: 2, e: ${f}")
+		assertNoError(t, err)
+		got, err := config.Resolve(ResolveOptions{AllowUnresolved: true})
+		assertNoError(t, err)
+		expected := &Config{objectOf(
+			"a", &Substitution{path: "b"},
+			"c", Int(1),
+			"d", Int(1),
+			"e", &valueWithAlternative{value: Int(2), alternative: &Substitution{path: "f"}},
+		)}
+		assertDeepEqual(t, got, expected)
+		assertEquals(t, got.IsResolved(), false)
+	})
+
+	t.Run("not create the fields of the undefined optional substitutions", func(t *testing.T) {
+		config, err := ParseStringUnresolved("a: ${?b}, c: x, d { e: ${?f} }")
+		assertNoError(t, err)
+		got, err := config.Resolve(ResolveOptions{DisableEnv: true})
+		assertNoError(t, err)
+		assertDeepEqual(t, got.root.(*Object).Keys(), []string{"c", "d"})
+		assertEquals(t, got.GetConfigOrPanic("d").root.(*Object).Len(), 0)
+		assertEquals(t, got.Has("a"), false)
+		assertEquals(t, got.String(), `{"c":"x", "d":{}}`)
+	})
+
+	t.Run("return the config itself if the root is not an object", func(t *testing.T) {
+		config := &Config{Array{Int(1)}}
+		got, err := config.Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertEquals(t, got, config)
+	})
+}
+
+func TestConfig_IsResolved(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		root     Value
+		expected bool
+	}{
+		{"object without substitutions", objectOf("a", Int(1)), true},
+		{"substitution in an object", objectOf("a", objectOf("b", &Substitution{path: "c"})), false},
+		{"substitution in an array", Array{Int(1), &Substitution{path: "a"}}, false},
+		{"substitution in a concatenation", objectOf("a", concatenation{String("a"), &Substitution{path: "b"}}), false},
+		{"value with alternative", objectOf("a", &valueWithAlternative{value: Int(1), alternative: &Substitution{path: "b"}}), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertEquals(t, (&Config{tc.root}).IsResolved(), tc.expected)
+		})
+	}
+}
+
+func TestSelfReferentialSubstitutions(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"append to the previous string", "path: /bin\npath: ${path}\":/opt/bin\"", `{"path":"/bin:/opt/bin"}`},
+		{"append to the previous array", "list: [1, 2]\nlist: ${list} [3]", `{"list":[1,2,3]}`},
+		{"merge with the previous object", "a { b: 1 }\na: ${a} { c: 2 }", `{"a":{"b":1, "c":2}}`},
+		{"refer to the previous value of a nested key", "a.b: 1\na { b: ${a.b}${a.b} }", `{"a":{"b":"11"}}`},
+		{"chain the previous values", "a: 1\na: ${a} 2\na: ${a} 3\nb: ${a}", `{"a":"1 2 3", "b":"1 2 3"}`},
+		{"keep the previous value if the optional self reference is the only definition", "a: 1\na: ${?a}", `{"a":1}`},
+		{"ignore the optional self reference without a previous value", "path: ${?path}\":/opt/bin\"", `{"path":":/opt/bin"}`},
+		{"ignore the optional self reference of an array without a previous value", "list: ${?list} [1]", `{"list":[1]}`},
+		{"resolve the self reference to the previous substitution", "b = 1, a = ${b}, a = ${a}", `{"b":1, "a":1}`},
+		{"override the substitution with the defined optional one", "DEF: d, OVR: o, a = ${DEF}, a = ${?OVR}", `{"DEF":"d", "OVR":"o", "a":"o"}`},
+		{"keep the substitution if the optional one is undefined", "DEF: d, a = ${DEF}, a = ${?OVR}", `{"DEF":"d", "a":"d"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseString(tc.input)
+			assertNoError(t, err)
+			assertEquals(t, got.String(), tc.expected)
+		})
+	}
+
+	t.Run("resolve the self reference to the value of the fallback config", func(t *testing.T) {
+		application, err := ParseStringUnresolved(`path: ${path}":/opt/bin", list: ${list} [2]`)
+		assertNoError(t, err)
+		reference, err := ParseStringUnresolved(`path: /bin, list: [1]`)
+		assertNoError(t, err)
+		got, err := application.WithFallback(reference).Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"path":"/bin:/opt/bin", "list":[1,2]}`)
+	})
+
+	t.Run("return an error if the self reference does not have a previous value", func(t *testing.T) {
+		got, err := ParseString(`path: ${path}":/opt/bin"`)
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not resolve substitution: ${path} to a value"))
+	})
+
+	t.Run("return an error if the substitutions refer to each other", func(t *testing.T) {
+		got, err := ParseString(`a: ${b}, b: ${a}`)
+		assertNil(t, got)
+		assertError(t, err, errors.New("detected substitution cycle: ${a}"))
+	})
+}
+
+func TestJoinConcatenation(t *testing.T) {
+	t.Run("append the arrays ignoring the whitespaces between them", func(t *testing.T) {
+		got, err := joinConcatenation(concatenation{Array{Int(1)}, String(`" "`), Array{Int(2)}})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, Array{Int(1), Int(2)})
+	})
+
+	t.Run("merge the objects without modifying them", func(t *testing.T) {
+		first := objectOf("a", objectOf("b", Int(1)))
+		got, err := joinConcatenation(concatenation{first, String(`" "`), objectOf("a", objectOf("c", Int(2)))})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, objectOf("a", objectOf("b", Int(1), "c", Int(2))))
+		assertDeepEqual(t, first, objectOf("a", objectOf("b", Int(1))))
+	})
+
+	t.Run("leave out the undefined values", func(t *testing.T) {
+		got, err := joinConcatenation(concatenation{nil, String("a"), concatenation{String("b"), nil}})
+		assertNoError(t, err)
+		assertDeepEqual(t, got, concatenation{String("a"), String("b")})
+	})
+
+	t.Run("return nil if all the values are undefined", func(t *testing.T) {
+		got, err := joinConcatenation(concatenation{nil})
+		assertNoError(t, err)
+		assertNil(t, got)
+	})
+
+	t.Run("return an error if an array is concatenated with a string", func(t *testing.T) {
+		got, err := joinConcatenation(concatenation{Array{Int(1)}, String("a")})
+		assertNil(t, got)
+		assertError(t, err, invalidConcatenationError())
+	})
+}
+
+func TestResolveEnv(t *testing.T) {
+	t.Run("look up the substitutions in the given environment map", func(t *testing.T) {
+		t.Parallel()
+		got, err := ParseStringWithOptions("home: ${HOME}, user: ${?USER}", ParseOptions{Env: EnvMap{"HOME": "/home/app"}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("home"), "/home/app")
+		assertEquals(t, got.Has("user"), false)
+	})
+
+	t.Run("look up the substitutions with the given environment function", func(t *testing.T) {
+		t.Parallel()
+		env := EnvFunc(func(key string) (string, bool) { return "value of " + key, true })
+		got, err := ParseStringWithOptions("a: ${A}", ParseOptions{Env: env})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("a"), "value of A")
+	})
+
+	t.Run("look up the substitutions of the included files in the given environment", func(t *testing.T) {
+		t.Parallel()
+		got, err := ParseResourceWithOptions("testdata/include-env.conf", ParseOptions{Env: EnvMap{"HOCON_HOME": "/home/app"}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("home"), "/home/app")
+	})
+
+	t.Run("not look up the substitutions in the environment if it is disabled", func(t *testing.T) {
+		t.Parallel()
+		got, err := ParseStringWithOptions("a: ${A}", ParseOptions{Env: EnvMap{"A": "a"}, DisableEnv: true})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not resolve substitution: ${A} to a value"))
+	})
+
+	t.Run("resolve the unresolved config with the given environment", func(t *testing.T) {
+		t.Parallel()
+		config, err := ParseStringUnresolved("a: ${A}")
+		assertNoError(t, err)
+		got, err := config.Resolve(ResolveOptions{Env: EnvMap{"A": "a"}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("a"), "a")
+	})
+}
+
+func TestRelativeIncludeSubstitutions(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			"look up the substitution relative to the include first",
+			"y: root\nfoo { include \"bar.conf\" }",
+			`{"y":"root", "foo":{"x":"included", "y":"included"}}`,
+		},
+		{
+			"fall back to the substitution from the root",
+			"root: 1\nself: [0]\nfoo { include \"root.conf\" }",
+			`{"root":1, "self":[0], "foo":{"z":1, "self":[0,2]}}`,
+		},
+		{
+			"refer to the previous value of the included key with a self reference",
+			"foo { self: [1] }\nfoo { include \"root.conf\" }\nroot: 0",
+			`{"foo":{"self":[1,2], "z":0}, "root":0}`,
+		},
+		{
+			"prefix the substitutions with the dotted path of the include",
+			"a.b { include \"bar.conf\" }",
+			`{"a":{"b":{"x":"included", "y":"included"}}}`,
+		},
+		{
+			"prefix the substitutions with the path of the nested includes",
+			"foo { include \"nested.conf\" }",
+			`{"foo":{"bar":{"x":"included", "y":"included"}}}`,
+		},
+		{
+			"not prefix the substitutions of the includes at the root",
+			"include \"bar.conf\"",
+			`{"x":"included", "y":"included"}`,
+		},
+		{
+			"not prefix the substitutions of the includes in the array elements",
+			"y: root\nlist: [{ include \"bar.conf\" }]",
+			`{"y":"root", "list":[{"x":"root", "y":"included"}]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseStringWithOptions(tc.input, ParseOptions{BaseDir: "testdata/relative"})
+			assertNoError(t, err)
+			assertEquals(t, got.String(), tc.expected)
+		})
+	}
+
+	t.Run("keep the prefix of the unresolved substitutions", func(t *testing.T) {
+		application, err := ParseStringUnresolved(`foo { include "testdata/relative/root.conf" }, foo.self: [1]`)
+		assertNoError(t, err)
+		reference, err := ParseStringUnresolved("foo.root: foo, root: root")
+		assertNoError(t, err)
+		got, err := application.WithFallback(reference).Resolve(ResolveOptions{})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("foo.z"), "foo")
+	})
+}
@@ -0,0 +1,88 @@
+package hocon
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Resolver resolves the substitutions with a scheme prefix, e.g. ${vault:db/password} is resolved by calling
+// the Resolve method of the resolver registered for the "vault" scheme with the key "db/password"
+type Resolver interface {
+	// Resolve returns the value of the given key, reports false if the key does not exist
+	Resolve(key string) (Value, bool, error)
+}
+
+// ResolverFunc type is an adapter to use an ordinary function as a Resolver
+type ResolverFunc func(key string) (Value, bool, error)
+
+// Resolve method calls f(key)
+func (f ResolverFunc) Resolve(key string) (Value, bool, error) {
+	return f(key)
+}
+
+// Env provides the environment variables for the substitutions
+type Env interface {
+	// LookupEnv returns the value of the environment variable of the given key, reports false if it is not set
+	LookupEnv(key string) (string, bool)
+}
+
+// EnvFunc type is an adapter to use an ordinary function like os.LookupEnv as an Env
+type EnvFunc func(key string) (string, bool)
+
+// LookupEnv method calls f(key)
+func (f EnvFunc) LookupEnv(key string) (string, bool) {
+	return f(key)
+}
+
+// EnvMap type is an Env with the environment variables in a map
+type EnvMap map[string]string
+
+// LookupEnv method returns the value of the given key in the map
+func (m EnvMap) LookupEnv(key string) (string, bool) {
+	value, ok := m[key]
+	return value, ok
+}
+
+// EnvResolver function returns a Resolver that resolves the keys to the environment variables, e.g. ${env:HOME},
+// the variables are looked up in the Env of the ResolveOptions and not looked up at all if DisableEnv is set
+func EnvResolver() Resolver {
+	return envResolver{}
+}
+
+// envResolver is replaced by the environment of the ResolveOptions while resolving, it looks up the environment
+// of the process when it is used on its own
+type envResolver struct{}
+
+// Resolve method returns the environment variable of the process with the given key
+func (envResolver) Resolve(key string) (Value, bool, error) {
+	return lookupEnvValue(ResolveOptions{}, key)
+}
+
+// lookupEnvValue returns the environment variable of the given key in the environment of the options
+func lookupEnvValue(options ResolveOptions, key string) (Value, bool, error) {
+	value, ok := options.lookupEnv(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	return String(value), true, nil
+}
+
+// FileResolver function returns a Resolver that resolves the keys to the contents of the files at the key paths
+// without the trailing newline, e.g. ${file:/run/secrets/db}, the files that do not exist are reported as not found
+func FileResolver() Resolver {
+	return ResolverFunc(func(key string) (Value, bool, error) {
+		content, err := os.ReadFile(key)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil, false, nil
+			}
+
+			return nil, false, err
+		}
+
+		return String(strings.TrimSuffix(strings.TrimSuffix(string(content), "\n"), "\r")), true, nil
+	})
+}
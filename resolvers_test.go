@@ -0,0 +1,123 @@
+package hocon
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchemeSubstitutions(t *testing.T) {
+	secrets := map[string]string{"db/password": "secret"}
+	errSealed := errors.New("vault is sealed")
+	vault := ResolverFunc(func(key string) (Value, bool, error) {
+		if key == "broken" {
+			return nil, false, errSealed
+		}
+
+		value, ok := secrets[key]
+
+		return String(value), ok, nil
+	})
+	opts := ParseOptions{Resolvers: map[string]Resolver{"vault": vault}}
+
+	t.Run("resolve the substitution with the resolver of the scheme", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`db { password: ${vault:db/password}, url: "postgres://app:"${vault:db/password}"@db" }`, opts)
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("db.password"), "secret")
+		assertEquals(t, got.GetStringOrPanic("db.url"), "postgres://app:secret@db")
+	})
+
+	t.Run("ignore the optional substitution if the resolver does not find the key", func(t *testing.T) {
+		got, err := ParseStringWithOptions("a: 1\na: ${?vault:missing}", opts)
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("a"), 1)
+	})
+
+	t.Run("return an error if the resolver does not find the key", func(t *testing.T) {
+		got, err := ParseStringWithOptions("a: ${vault:missing}", opts)
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not resolve substitution: ${vault:missing} to a value"))
+	})
+
+	t.Run("return the error of the resolver with the position of the substitution", func(t *testing.T) {
+		got, err := ParseStringWithOptions("a: 1\nb: ${vault:broken}", opts)
+		assertNil(t, got)
+		assertError(t, err, invalidSubstitutionError("could not resolve substitution: ${vault:broken}, vault is sealed", 2, 4))
+		assertEquals(t, errors.Is(err, errSealed), true)
+	})
+
+	t.Run("return an error if there is no resolver for the scheme", func(t *testing.T) {
+		got, err := ParseString("a: ${consul:db}")
+		assertNil(t, got)
+		assertError(t, err, invalidSubstitutionError(`could not resolve substitution: ${consul:db}, no resolver for the scheme: "consul"`, 1, 4))
+	})
+
+	t.Run("resolve the scheme substitutions of the unresolved config", func(t *testing.T) {
+		config, err := ParseStringUnresolved("a: ${vault:db/password}")
+		assertNoError(t, err)
+		got, err := config.Resolve(ResolveOptions{Resolvers: opts.Resolvers})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("a"), "secret")
+	})
+}
+
+func TestEnvResolver(t *testing.T) {
+	t.Setenv("HOCON_TEST_ENV", "value")
+
+	t.Run("resolve the key to the environment variable", func(t *testing.T) {
+		got, ok, err := EnvResolver().Resolve("HOCON_TEST_ENV")
+		assertNoError(t, err)
+		assertEquals(t, ok, true)
+		assertEquals(t, got, String("value"))
+	})
+
+	t.Run("report false if the environment variable is not set", func(t *testing.T) {
+		got, ok, err := EnvResolver().Resolve("HOCON_TEST_MISSING_ENV")
+		assertNoError(t, err)
+		assertEquals(t, ok, false)
+		assertNil(t, got)
+	})
+
+	t.Run("look up the environment of the options", func(t *testing.T) {
+		resolvers := map[string]Resolver{"env": EnvResolver()}
+		got, err := ParseStringWithOptions("a: ${env:HOCON_TEST_ENV}", ParseOptions{Resolvers: resolvers, Env: EnvMap{"HOCON_TEST_ENV": "injected"}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("a"), "injected")
+	})
+
+	t.Run("not look up the environment if it is disabled", func(t *testing.T) {
+		resolvers := map[string]Resolver{"env": EnvResolver()}
+		got, err := ParseStringWithOptions("a: ${env:HOCON_TEST_ENV}", ParseOptions{Resolvers: resolvers, DisableEnv: true})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not resolve substitution: ${env:HOCON_TEST_ENV} to a value"))
+	})
+}
+
+func TestFileResolver(t *testing.T) {
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "db")
+	assertNoError(t, os.WriteFile(secret, []byte("secret\n"), 0o600))
+
+	t.Run("resolve the key to the content of the file without the trailing newline", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`a: ${file:`+secret+`}`, ParseOptions{Resolvers: map[string]Resolver{"file": FileResolver()}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetStringOrPanic("a"), "secret")
+	})
+
+	t.Run("report false if the file does not exist", func(t *testing.T) {
+		got, ok, err := FileResolver().Resolve(filepath.Join(dir, "missing"))
+		assertNoError(t, err)
+		assertEquals(t, ok, false)
+		assertNil(t, got)
+	})
+
+	t.Run("return the error if the file cannot be read", func(t *testing.T) {
+		got, ok, err := FileResolver().Resolve(dir)
+		assertEquals(t, ok, false)
+		assertNil(t, got)
+		if err == nil {
+			t.Fatalf("expected an error but did not get one")
+		}
+	})
+}
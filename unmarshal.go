@@ -0,0 +1,525 @@
+package hocon
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	tagName         = "hocon"
+	defaultTagName  = "default"
+	validateTagName = "validate"
+)
+
+var (
+	configType          = reflect.TypeOf(&Config{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Unmarshal method decodes the root of the configuration into the value pointed to by target
+//
+// structs are filled by matching the config keys with the `hocon:"key"` tags of the exported fields,
+// the field name is used (case-insensitively) if the field does not have a tag and the fields tagged
+// with `hocon:"-"` are skipped. Nested structs, slices, arrays, maps with string keys and pointers are
+// decoded recursively, scalar values are converted with the same rules of the GetInt, GetBoolean,
+// GetDuration... methods. Fields without a matching config key are left untouched. The values of the struct fields
+// that cannot be decoded are collected with the violations of the validate tags into a ValidationError.
+func (c *Config) Unmarshal(target interface{}) error {
+	return unmarshal(c.root, "", target)
+}
+
+// UnmarshalPath method finds the value at the given path and decodes it into the value pointed to by target
+// with the same rules of the Unmarshal method
+func (c *Config) UnmarshalPath(path string, target interface{}) error {
+	value := c.get(path)
+	if value == nil {
+		return fmt.Errorf("config value not found at path: %s", path)
+	}
+
+	return unmarshal(value, path, target)
+}
+
+// UnmarshalStrict method decodes the configuration like the Unmarshal method, but returns an error listing
+// all the config paths that did not map to any struct field, so misspelled keys do not silently fall through
+func (c *Config) UnmarshalStrict(target interface{}) error {
+	d := newDecoder()
+	if err := d.unmarshal(c.root, "", target); err != nil {
+		return err
+	}
+
+	if err := d.validationError(); err != nil {
+		return err
+	}
+
+	if unused := d.unused(c.root, ""); len(unused) > 0 {
+		return unusedKeysError(unused)
+	}
+
+	return nil
+}
+
+// CheckUnused method returns the config paths that would not map to any struct field if the configuration
+// was decoded into the target, the target itself is not modified
+func (c *Config) CheckUnused(target interface{}) ([]string, error) {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("unmarshal target must be a non-nil pointer, got: %T", target)
+	}
+
+	d := newDecoder()
+	if err := d.unmarshal(c.root, "", reflect.New(rv.Elem().Type()).Interface()); err != nil {
+		return nil, err
+	}
+
+	return d.unused(c.root, ""), nil
+}
+
+func unmarshal(value Value, path string, target interface{}) error {
+	d := newDecoder()
+	if err := d.unmarshal(value, path, target); err != nil {
+		return err
+	}
+
+	return d.validationError()
+}
+
+// decoder walks the configuration tree and fills the Go values, it keeps track of the config paths
+// decoded into structs and the keys used by their fields to find the unused keys afterwards,
+// and collects the violations of the validate tags and the errors of the struct fields to report all of them at once
+type decoder struct {
+	structs    map[string]bool
+	used       map[string]bool
+	violations []Violation
+	errs       []error
+}
+
+func newDecoder() *decoder {
+	return &decoder{structs: make(map[string]bool), used: make(map[string]bool)}
+}
+
+func (d *decoder) unmarshal(value Value, path string, target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("unmarshal target must be a non-nil pointer, got: %T", target)
+	}
+
+	return d.decode(value, path, typeName(rv.Elem().Type()), rv.Elem())
+}
+
+// unused returns the paths of the keys that are found in the objects decoded into structs
+// but not used by any of the struct fields
+func (d *decoder) unused(value Value, path string) []string {
+	var paths []string
+
+	switch v := value.(type) {
+	case *Object:
+		for key, element := range v.All() {
+			keyPath := joinPath(path, key)
+			if d.structs[path] && !d.used[keyPath] {
+				paths = append(paths, keyPath)
+				continue
+			}
+
+			paths = append(paths, d.unused(element, keyPath)...)
+		}
+	case Array:
+		for i, element := range v {
+			paths = append(paths, d.unused(element, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return paths
+}
+
+func (d *decoder) decode(value Value, path, field string, rv reflect.Value) error {
+	if value == nil { // an undefined value is treated like an absent key
+		return nil
+	}
+
+	if value.Type() == NullType {
+		rv.Set(reflect.Zero(rv.Type()))
+		return nil
+	}
+
+	if rv.Type() == configType {
+		object, ok := value.(*Object)
+		if !ok {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.Set(reflect.ValueOf(object.ToConfig()))
+
+		return nil
+	}
+
+	if rv.Kind() == reflect.Interface && rv.NumMethod() == 0 {
+		if plain := plainValue(value); plain != nil {
+			rv.Set(reflect.ValueOf(plain))
+		}
+
+		return nil
+	}
+
+	if reflect.TypeOf(value).AssignableTo(rv.Type()) {
+		rv.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if rv.Type() == durationType {
+		duration, ok := durationValue(value)
+		if !ok {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.SetInt(int64(duration))
+
+		return nil
+	}
+
+	if rv.CanAddr() && rv.Addr().Type().Implements(textUnmarshalerType) && isScalar(value) {
+		if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value.String())); err != nil {
+			return unmarshalError(path, field, err.Error())
+		}
+
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+
+		return d.decode(value, path, field, rv.Elem())
+	case reflect.String:
+		if !isScalar(value) {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.SetString(value.String())
+	case reflect.Bool:
+		b, ok := booleanValue(value)
+		if !ok {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := intValue(value)
+		if !ok || rv.OverflowInt(int64(i)) {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.SetInt(int64(i))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, ok := intValue(value)
+		if !ok || i < 0 || rv.OverflowUint(uint64(i)) {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, ok := float64Value(value)
+		if i, isInt := value.(Int); isInt {
+			f, ok = float64(i), true
+		}
+
+		if !ok || rv.OverflowFloat(f) {
+			return d.typeError(value, path, field, rv)
+		}
+
+		rv.SetFloat(f)
+	case reflect.Struct:
+		return d.decodeStruct(value, path, field, rv)
+	case reflect.Map:
+		return d.decodeMap(value, path, field, rv)
+	case reflect.Slice, reflect.Array:
+		return d.decodeArray(value, path, field, rv)
+	default:
+		return unmarshalError(path, field, fmt.Sprintf("unsupported type: %s", rv.Type()))
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeStruct(value Value, path, field string, rv reflect.Value) error {
+	object, ok := value.(*Object)
+	if !ok {
+		return d.typeError(value, path, field, rv)
+	}
+
+	d.structs[path] = true
+
+	for _, f := range structFields(rv.Type()) {
+		fieldName := field + dotToken + f.field
+		keyPath := joinPath(path, f.name)
+
+		key, fieldValue, found := object.lookup(f.name)
+		found = found && fieldValue != nil // an undefined value falls back to the default like an absent key
+
+		if found {
+			keyPath = joinPath(path, key)
+			d.used[keyPath] = true
+		} else if f.hasDefault {
+			defaultValue, err := parseValue(f.defaultValue)
+			if err != nil {
+				return unmarshalError(keyPath, fieldName, fmt.Sprintf("invalid default value: %q, %s", f.defaultValue, err))
+			}
+
+			fieldValue = defaultValue
+		} else if f.kind == reflect.Struct {
+			fieldValue = NewObject() // decode the nested struct anyway to apply its defaults and validations
+		}
+
+		fieldRV, ok := fieldByIndexNoAlloc(rv, f.index)
+		if found {
+			fieldRV = fieldByIndex(rv, f.index) // the nil embedded pointers are allocated only for the found keys
+		} else if !ok {
+			fieldRV = reflect.New(rv.Type().FieldByIndex(f.index).Type).Elem()
+		}
+
+		if fieldValue != nil {
+			if err := d.decode(fieldValue, keyPath, fieldName, fieldRV); err != nil {
+				var unmarshalErr *UnmarshalError
+				if !errors.As(err, &unmarshalErr) {
+					return err
+				}
+
+				d.errs = append(d.errs, unmarshalErr) // reported with the violations of the other fields
+				d.violations = append(d.violations, Violation{Path: unmarshalErr.path, Message: unmarshalErr.message})
+
+				continue
+			}
+		}
+
+		present := (found || f.hasDefault) && fieldValue != nil && fieldValue.Type() != NullType
+		if err := d.validate(f, keyPath, fieldName, present, fieldRV); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeMap(value Value, path, field string, rv reflect.Value) error {
+	mapType := rv.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return unmarshalError(path, field, fmt.Sprintf("unsupported map key type: %s", mapType.Key()))
+	}
+
+	object, ok := value.(*Object)
+	if !ok {
+		return d.typeError(value, path, field, rv)
+	}
+
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMapWithSize(mapType, object.Len()))
+	}
+
+	for key, element := range object.All() {
+		if element == nil {
+			continue
+		}
+
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := d.decode(element, joinPath(path, key), fmt.Sprintf("%s[%s]", field, key), elem); err != nil {
+			return err
+		}
+
+		rv.SetMapIndex(reflect.ValueOf(key).Convert(mapType.Key()), elem)
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeArray(value Value, path, field string, rv reflect.Value) error {
+	array, ok := value.(Array)
+	if !ok {
+		return d.typeError(value, path, field, rv)
+	}
+
+	if rv.Kind() == reflect.Array {
+		if rv.Len() != len(array) {
+			return unmarshalError(path, field, fmt.Sprintf("array of %d elements cannot be decoded into %s", len(array), rv.Type()))
+		}
+	} else {
+		rv.Set(reflect.MakeSlice(rv.Type(), len(array), len(array)))
+	}
+
+	for i, element := range array {
+		if err := d.decode(element, fmt.Sprintf("%s[%d]", path, i), fmt.Sprintf("%s[%d]", field, i), rv.Index(i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) typeError(value Value, path, field string, rv reflect.Value) *UnmarshalError {
+	return unmarshalError(path, field, fmt.Sprintf("cannot parse value: %s to %s", value.String(), rv.Type()))
+}
+
+// structField holds the config key of an exported struct field, the index sequence to reach it
+// and the default value and validation rules from the struct tags
+type structField struct {
+	index        []int
+	name         string
+	field        string
+	omitEmpty    bool
+	kind         reflect.Kind
+	defaultValue string
+	hasDefault   bool
+	rules        string
+}
+
+// structFields returns the fields of the given struct type that can be mapped to config keys,
+// the fields of the embedded structs without a tag are promoted to the parent struct
+func structFields(structType reflect.Type) []structField {
+	var fields []structField
+
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		tag, hasTag := f.Tag.Lookup(tagName)
+		name, tagOptions, _ := strings.Cut(tag, commaToken)
+
+		if name == "-" {
+			continue
+		}
+
+		fieldType := f.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if f.Anonymous && !hasTag && fieldType.Kind() == reflect.Struct {
+			if !f.IsExported() && f.Type.Kind() == reflect.Ptr {
+				continue // the unexported embedded pointer cannot be allocated, like in the encoding/json package
+			}
+
+			for _, embedded := range structFields(fieldType) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+
+		defaultValue, hasDefault := f.Tag.Lookup(defaultTagName)
+
+		fields = append(fields, structField{
+			index:        []int{i},
+			name:         name,
+			field:        f.Name,
+			omitEmpty:    tagOptions == "omitempty",
+			kind:         f.Type.Kind(),
+			defaultValue: defaultValue,
+			hasDefault:   hasDefault,
+			rules:        f.Tag.Get(validateTagName),
+		})
+	}
+
+	return fields
+}
+
+// fieldByIndex returns the nested field of the given struct, allocating the nil embedded pointers on the way
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(x)
+	}
+
+	return rv
+}
+
+// lookup returns the value with the given key, if there is no exact match
+// the first key that matches case-insensitively is used
+func (o *Object) lookup(key string) (string, Value, bool) {
+	if value, ok := o.Get(key); ok {
+		return key, value, true
+	}
+
+	for k, value := range o.All() {
+		if strings.EqualFold(k, key) {
+			return k, value, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// plainValue converts the given value to the plain Go types, used while decoding into the empty interfaces
+func plainValue(value Value) interface{} {
+	switch val := value.(type) {
+	case *Object:
+		m := make(map[string]interface{}, val.Len())
+		for k, v := range val.All() {
+			if v != nil {
+				m[k] = plainValue(v)
+			}
+		}
+
+		return m
+	case Array:
+		s := make([]interface{}, 0, len(val))
+		for _, v := range val {
+			s = append(s, plainValue(v))
+		}
+
+		return s
+	case Int:
+		return int(val)
+	case Float32:
+		return float32(val)
+	case Float64:
+		return float64(val)
+	case Boolean:
+		return bool(val)
+	case Duration:
+		return time.Duration(val)
+	case nil, Null:
+		return nil
+	default:
+		return value.String()
+	}
+}
+
+func isScalar(value Value) bool {
+	valueType := value.Type()
+	return valueType != ObjectType && valueType != ArrayType
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + dotToken + key
+}
+
+func typeName(t reflect.Type) string {
+	if name := t.Name(); name != "" {
+		return name
+	}
+
+	return t.String()
+}
@@ -0,0 +1,446 @@
+package hocon
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+type testDatabase struct {
+	Host    string        `hocon:"host"`
+	Port    uint16        `hocon:"port"`
+	Timeout time.Duration `hocon:"timeout"`
+	Hosts   []string      `hocon:"hosts"`
+}
+
+type testSettings struct {
+	Name     string            `hocon:"name"`
+	Enabled  bool              `hocon:"enabled"`
+	Ratio    float64           `hocon:"ratio"`
+	Database testDatabase      `hocon:"db"`
+	Replica  *testDatabase     `hocon:"replica"`
+	Limits   map[string]int    `hocon:"limits"`
+	Extra    interface{}       `hocon:"extra"`
+	Raw      Value             `hocon:"raw"`
+	Nested   *Config           `hocon:"nested"`
+	Labels   map[string]string `hocon:"labels"`
+	Ignored  string            `hocon:"-"`
+	Retries  int
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("return an error if the target is not a non-nil pointer", func(t *testing.T) {
+		config := &Config{objectOf("a", Int(1))}
+		var settings testSettings
+		err := config.Unmarshal(settings)
+		assertError(t, err, errors.New("unmarshal target must be a non-nil pointer, got: hocon.testSettings"))
+	})
+
+	t.Run("decode the configuration into the tagged struct fields", func(t *testing.T) {
+		config, err := ParseString(`
+			name: service
+			enabled: yes
+			ratio: 1
+			retries: "3"
+			db {
+				host: localhost
+				port: 5432
+				timeout: 5 seconds
+				hosts: [a, b]
+			}
+			replica.host: replica
+			limits { read: 10, write: 20 }
+			extra { a: [1, true, null] }
+			raw: [1, 2]
+			nested { x: 1 }
+			labels { version: 2 }
+			Ignored: value
+		`)
+		assertNoError(t, err)
+
+		var got testSettings
+		err = config.Unmarshal(&got)
+		assertNoError(t, err)
+		expected := testSettings{
+			Name:     "service",
+			Enabled:  true,
+			Ratio:    1,
+			Database: testDatabase{Host: "localhost", Port: 5432, Timeout: 5 * time.Second, Hosts: []string{"a", "b"}},
+			Replica:  &testDatabase{Host: "replica"},
+			Limits:   map[string]int{"read": 10, "write": 20},
+			Extra:    map[string]interface{}{"a": []interface{}{1, true, nil}},
+			Raw:      Array{Int(1), Int(2)},
+			Nested:   &Config{objectOf("x", Int(1))},
+			Labels:   map[string]string{"version": "2"},
+			Retries:  3,
+		}
+		assertDeepEqual(t, got.Nested, expected.Nested)
+		got.Nested, expected.Nested = nil, nil
+		assertDeepEqual(t, got, expected)
+	})
+
+	t.Run("leave the fields untouched if there is no value for them in the configuration", func(t *testing.T) {
+		config := &Config{objectOf("name", String("service"))}
+		got := testSettings{Retries: 5}
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, testSettings{Name: "service", Retries: 5})
+	})
+
+	t.Run("treat the undefined values like the absent keys", func(t *testing.T) {
+		config := &Config{objectOf("a", nil, "b", String("x"))}
+		var gotMap map[string]string
+		err := config.Unmarshal(&gotMap)
+		assertNoError(t, err)
+		assertDeepEqual(t, gotMap, map[string]string{"b": "x"})
+
+		var gotInterface interface{}
+		err = config.Unmarshal(&gotInterface)
+		assertNoError(t, err)
+		assertDeepEqual(t, gotInterface, map[string]interface{}{"b": "x"})
+
+		var gotString string
+		err = config.UnmarshalPath("a", &gotString)
+		assertError(t, err, errors.New("config value not found at path: a"))
+	})
+
+	t.Run("set the zero value if the config value is null", func(t *testing.T) {
+		config := &Config{objectOf("name", null, "replica", null)}
+		got := testSettings{Name: "service", Replica: &testDatabase{}}
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, testSettings{})
+	})
+
+	t.Run("promote the fields of the embedded structs", func(t *testing.T) {
+		type embedded struct {
+			testDatabase
+			Name string `hocon:"name"`
+		}
+		config := &Config{objectOf("name", String("a"), "host", String("b"))}
+		var got embedded
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, embedded{testDatabase: testDatabase{Host: "b"}, Name: "a"})
+	})
+
+	t.Run("skip the unexported embedded struct pointers", func(t *testing.T) {
+		type outer struct {
+			*testDatabase
+			Name string `hocon:"name"`
+		}
+		config := &Config{objectOf("name", String("y"), "host", String("b"))}
+		var got outer
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, outer{Name: "y"})
+	})
+
+	t.Run("allocate the embedded struct pointers only if their keys are found", func(t *testing.T) {
+		type Inner struct {
+			Host string `hocon:"host" default:"localhost" validate:"required"`
+		}
+		type outer struct {
+			*Inner
+			Name string `hocon:"name"`
+		}
+		var got outer
+		err := (&Config{objectOf("name", String("y"))}).Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, outer{Name: "y"})
+
+		err = (&Config{objectOf("host", String("b"))}).Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, outer{Inner: &Inner{Host: "b"}, Name: "y"})
+	})
+
+	t.Run("decode the string values with the encoding.TextUnmarshaler implementation of the field", func(t *testing.T) {
+		config := &Config{objectOf("ip", String("127.0.0.1"))}
+		var got struct {
+			IP net.IP `hocon:"ip"`
+		}
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertEquals(t, got.IP.String(), "127.0.0.1")
+	})
+
+	t.Run("decode an array into a fixed size array", func(t *testing.T) {
+		config := &Config{objectOf("a", Array{Int(1), Int(2)})}
+		var got struct {
+			A [2]int `hocon:"a"`
+		}
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got.A, [2]int{1, 2})
+	})
+
+	t.Run("return an error with the full path and the field name if a value cannot be converted", func(t *testing.T) {
+		config := &Config{objectOf("db", objectOf("hosts", Array{String("a"), objectOf("b", Int(1))}))}
+		var got testSettings
+		err := config.Unmarshal(&got)
+		expectedError := fieldErrors(unmarshalError("db.hosts[1]", "testSettings.Database.Hosts[1]", `cannot parse value: {"b":1} to string`))
+		assertError(t, err, expectedError)
+	})
+
+	t.Run("collect the values that cannot be decoded with the violations", func(t *testing.T) {
+		type database struct {
+			Host string `hocon:"host" validate:"required"`
+			Port int    `hocon:"port"`
+			Mode string `hocon:"mode" validate:"oneof=primary|replica"`
+		}
+		config := &Config{objectOf("db", objectOf("port", String("abc"), "mode", String("backup")))}
+		var got struct {
+			Database database `hocon:"db"`
+		}
+		err := config.Unmarshal(&got)
+		assertEquals(t, err.Error(), "invalid configuration: db.host is required, db.port cannot parse value: abc to int, "+
+			"db.mode must be one of [primary, replica]")
+
+		var unmarshalErr *UnmarshalError
+		assertEquals(t, errors.As(err, &unmarshalErr), true)
+		assertEquals(t, unmarshalErr.path, "db.port")
+		assertEquals(t, unmarshalErr.message, "cannot parse value: abc to int")
+	})
+
+	t.Run("return an error if the value overflows the field", func(t *testing.T) {
+		config := &Config{objectOf("db", objectOf("port", Int(70000)))}
+		var got testSettings
+		err := config.Unmarshal(&got)
+		expectedError := fieldErrors(unmarshalError("db.port", "testSettings.Database.Port", "cannot parse value: 70000 to uint16"))
+		assertError(t, err, expectedError)
+	})
+
+	t.Run("return an error if the value is not a duration", func(t *testing.T) {
+		config := &Config{objectOf("db", objectOf("timeout", String("soon")))}
+		var got testSettings
+		err := config.Unmarshal(&got)
+		expectedError := fieldErrors(unmarshalError("db.timeout", "testSettings.Database.Timeout", "cannot parse value: soon to time.Duration"))
+		assertError(t, err, expectedError)
+	})
+
+	t.Run("return an error if the struct is decoded from a non-object value", func(t *testing.T) {
+		config := &Config{Array{Int(1)}}
+		var got testSettings
+		err := config.Unmarshal(&got)
+		expectedError := unmarshalError("", "testSettings", "cannot parse value: [1] to hocon.testSettings")
+		assertError(t, err, expectedError)
+	})
+
+	t.Run("return an error for the unsupported field types", func(t *testing.T) {
+		config := &Config{objectOf("a", Int(1))}
+		var got struct {
+			A chan int `hocon:"a"`
+		}
+		err := config.Unmarshal(&got)
+		expectedError := fieldErrors(unmarshalError("a", "struct { A chan int \"hocon:\\\"a\\\"\" }.A", "unsupported type: chan int"))
+		assertError(t, err, expectedError)
+	})
+}
+
+// fieldErrors returns the ValidationError of the given errors of the struct fields
+func fieldErrors(errs ...*UnmarshalError) *ValidationError {
+	validationErr := &ValidationError{}
+	for _, err := range errs {
+		validationErr.violations = append(validationErr.violations, Violation{Path: err.path, Message: err.message})
+		validationErr.errs = append(validationErr.errs, err)
+	}
+
+	return validationErr
+}
+
+func TestUnmarshalPath(t *testing.T) {
+	config := &Config{objectOf("a", objectOf("db", objectOf("host", String("localhost"), "port", String("x"))))}
+
+	t.Run("decode the value at the given path", func(t *testing.T) {
+		var got testDatabase
+		err := config.UnmarshalPath("a.db", &got)
+		assertError(t, err, fieldErrors(unmarshalError("a.db.port", "testDatabase.Port", "cannot parse value: x to uint16")))
+		assertEquals(t, got.Host, "localhost")
+	})
+
+	t.Run("return an error if there is no value at the given path", func(t *testing.T) {
+		var got testDatabase
+		err := config.UnmarshalPath("b", &got)
+		assertError(t, err, errors.New("config value not found at path: b"))
+	})
+}
+
+func TestUnmarshalStrict(t *testing.T) {
+	t.Run("decode the configuration if all the keys map to a struct field", func(t *testing.T) {
+		config := &Config{objectOf("db", objectOf("host", String("localhost"), "port", Int(5432)))}
+		var got testSettings
+		err := config.UnmarshalStrict(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got.Database, testDatabase{Host: "localhost", Port: 5432})
+	})
+
+	t.Run("return an error listing all the keys that do not map to any struct field", func(t *testing.T) {
+		config, err := ParseString(`
+			db { host: localhost, pool-sise: 10 }
+			replicas: [{ host: a }, { hots: b }]
+			limits { read: 1 }
+			extra { anything: goes }
+			unknown { a: 1 }
+		`)
+		assertNoError(t, err)
+
+		var got struct {
+			Database testDatabase   `hocon:"db"`
+			Replicas []testDatabase `hocon:"replicas"`
+			Limits   map[string]int `hocon:"limits"`
+			Extra    interface{}    `hocon:"extra"`
+		}
+		err = config.UnmarshalStrict(&got)
+		assertError(t, err, unusedKeysError([]string{"db.pool-sise", "replicas[1].hots", "unknown"}))
+
+		var unusedErr *UnusedKeysError
+		assertEquals(t, errors.As(err, &unusedErr), true)
+		assertDeepEqual(t, unusedErr.Paths(), []string{"db.pool-sise", "replicas[1].hots", "unknown"})
+	})
+
+	t.Run("return the decoding errors before checking the unused keys", func(t *testing.T) {
+		config := &Config{objectOf("db", objectOf("port", String("x")), "typo", Int(1))}
+		var got testSettings
+		err := config.UnmarshalStrict(&got)
+		assertError(t, err, fieldErrors(unmarshalError("db.port", "testSettings.Database.Port", "cannot parse value: x to uint16")))
+	})
+}
+
+func TestCheckUnused(t *testing.T) {
+	config := &Config{objectOf("name", String("service"), "db", objectOf("hots", String("localhost")))}
+
+	t.Run("return the unused keys without modifying the target", func(t *testing.T) {
+		var got testSettings
+		unused, err := config.CheckUnused(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, unused, []string{"db.hots"})
+		assertDeepEqual(t, got, testSettings{})
+	})
+
+	t.Run("return an error if the target is not a non-nil pointer", func(t *testing.T) {
+		unused, err := config.CheckUnused(testSettings{})
+		assertNil(t, unused)
+		assertError(t, err, errors.New("unmarshal target must be a non-nil pointer, got: hocon.testSettings"))
+	})
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	type server struct {
+		Host    string        `hocon:"host" default:"localhost"`
+		Timeout time.Duration `hocon:"timeout" default:"30s"`
+		Debug   bool          `hocon:"debug" default:"on"`
+		Tags    []string      `hocon:"tags" default:"[a, b]"`
+	}
+
+	type settings struct {
+		Server server `hocon:"server"`
+	}
+
+	t.Run("parse and use the default values if the keys are absent", func(t *testing.T) {
+		config := &Config{objectOf("server", objectOf("host", String("example.com")))}
+		var got settings
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, settings{server{Host: "example.com", Timeout: 30 * time.Second, Debug: true, Tags: []string{"a", "b"}}})
+	})
+
+	t.Run("apply the defaults of the nested structs even if their object is absent", func(t *testing.T) {
+		config := &Config{objectOf()}
+		var got settings
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, settings{server{Host: "localhost", Timeout: 30 * time.Second, Debug: true, Tags: []string{"a", "b"}}})
+	})
+
+	t.Run("use the default values if the values are undefined", func(t *testing.T) {
+		config := &Config{objectOf("server", objectOf("host", nil, "debug", String("off")))}
+		var got settings
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertDeepEqual(t, got, settings{server{Host: "localhost", Timeout: 30 * time.Second, Debug: false, Tags: []string{"a", "b"}}})
+	})
+
+	t.Run("return an error if the default value cannot be parsed", func(t *testing.T) {
+		config := &Config{objectOf()}
+		var got struct {
+			A int `hocon:"a" default:"{"`
+		}
+		err := config.Unmarshal(&got)
+		assertError(t, err, unmarshalError("a", "struct { A int \"hocon:\\\"a\\\" default:\\\"{\\\"\" }.A", `invalid default value: "{", invalid config object! at: 1:2, parenthesis do not match`))
+	})
+}
+
+func TestUnmarshalValidation(t *testing.T) {
+	type database struct {
+		Host    string        `hocon:"host" validate:"required"`
+		Port    int           `hocon:"port" validate:"required,min=1,max=65535"`
+		Mode    string        `hocon:"mode" validate:"oneof=primary|replica"`
+		Timeout time.Duration `hocon:"timeout" validate:"min=1s"`
+		Hosts   []string      `hocon:"hosts" validate:"min=1"`
+		Pool    *int          `hocon:"pool" default:"10" validate:"max=5"`
+	}
+
+	t.Run("decode the values that satisfy all the rules", func(t *testing.T) {
+		config := &Config{objectOf("host", String("a"), "port", Int(80), "mode", String("replica"), "pool", Int(2))}
+		var got database
+		err := config.Unmarshal(&got)
+		assertNoError(t, err)
+		assertEquals(t, *got.Pool, 2)
+	})
+
+	t.Run("collect all the violations into one error", func(t *testing.T) {
+		config := &Config{objectOf(
+			"db", objectOf("host", null, "port", Int(70000), "mode", String("backup"), "timeout", Duration(0), "hosts", Array{}),
+		)}
+		var got struct {
+			Database database `hocon:"db"`
+		}
+		err := config.Unmarshal(&got)
+		expectedError := &ValidationError{violations: []Violation{
+			{Path: "db.host", Message: "is required"},
+			{Path: "db.port", Message: "must be at most 65535"},
+			{Path: "db.mode", Message: "must be one of [primary, replica]"},
+			{Path: "db.timeout", Message: "must be at least 1s"},
+			{Path: "db.hosts", Message: "length must be at least 1"},
+			{Path: "db.pool", Message: "must be at most 5"},
+		}}
+		assertError(t, err, expectedError)
+		assertEquals(t, err.Error(), "invalid configuration: db.host is required, db.port must be at most 65535, "+
+			"db.mode must be one of [primary, replica], db.timeout must be at least 1s, db.hosts length must be at least 1, "+
+			"db.pool must be at most 5")
+	})
+
+	t.Run("report the absent required values", func(t *testing.T) {
+		config := &Config{objectOf("mode", String("primary"))}
+		var got database
+		err := config.Unmarshal(&got)
+		expectedError := &ValidationError{violations: []Violation{
+			{Path: "host", Message: "is required"},
+			{Path: "port", Message: "is required"},
+			{Path: "pool", Message: "must be at most 5"},
+		}}
+		assertError(t, err, expectedError)
+
+		var validationErr *ValidationError
+		assertEquals(t, errors.As(err, &validationErr), true)
+		assertDeepEqual(t, validationErr.Violations(), expectedError.violations)
+	})
+
+	t.Run("report the undefined required values", func(t *testing.T) {
+		config, err := ParseStringWithOptions("host = ${?UNSET}, port = 80, pool = 1", ParseOptions{DisableEnv: true})
+		assertNoError(t, err)
+		var got database
+		err = config.Unmarshal(&got)
+		assertError(t, err, &ValidationError{violations: []Violation{{Path: "host", Message: "is required"}}})
+	})
+
+	t.Run("return an error for the invalid rules", func(t *testing.T) {
+		config := &Config{objectOf("a", Int(1))}
+		var got struct {
+			A int `hocon:"a" validate:"min=x"`
+		}
+		err := config.Unmarshal(&got)
+		assertError(t, err, unmarshalError("a", "struct { A int \"hocon:\\\"a\\\" validate:\\\"min=x\\\"\" }.A", `invalid validate tag: "min=x", "x" is not a number`))
+	})
+}
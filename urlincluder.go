@@ -0,0 +1,216 @@
+package hocon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// URLIncluder is an Includer that downloads the sources of the url(...) includes (and the relative includes of the
+// downloaded sources) over HTTP and opens the other includes with the Fallback, the responses are cached by their
+// ETags, so the same URLIncluder should be reused to parse the configs again, the zero value is ready to use
+type URLIncluder struct {
+	// Client sends the requests, http.DefaultClient is used if it is nil
+	Client *http.Client
+	// Timeout limits the duration of a request including reading the response body, no limit if it is 0
+	Timeout time.Duration
+	// MaxBodySize limits the size of the response bodies in bytes, no limit if it is 0
+	MaxBodySize int64
+	// AllowedHosts are the hosts (with the port if it is not the default one of the scheme) that the sources can be
+	// downloaded from including the redirects, all the hosts are allowed if it is empty
+	AllowedHosts []string
+	// Fallback opens the includes that are not downloaded over HTTP, FileIncluder is used if it is nil
+	Fallback Includer
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse // the responses with an ETag by the URL
+}
+
+type cachedResponse struct {
+	etag string
+	body []byte
+}
+
+// Include method downloads the source of the url(...) include, or the bare include of a downloaded source resolved
+// relative to its URL, the other includes are opened with the Fallback
+func (u *URLIncluder) Include(include Include) ([]IncludeSource, error) {
+	var (
+		location *url.URL
+		err      error
+	)
+
+	switch {
+	case include.Kind == URLInclude:
+		location, err = url.Parse(include.Location)
+	case include.Kind == BareInclude && isHTTPURL(include.Parent):
+		location, err = url.Parse(include.Parent)
+		if err == nil {
+			location, err = location.Parse(include.Location)
+		}
+	default:
+		return u.fallback().Include(include)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := u.download(location)
+	if err != nil {
+		return nil, err
+	}
+
+	return []IncludeSource{{Name: location.String(), Reader: io.NopCloser(bytes.NewReader(body))}}, nil
+}
+
+func (u *URLIncluder) fallback() Includer {
+	if u.Fallback != nil {
+		return u.Fallback
+	}
+
+	return FileIncluder()
+}
+
+// download returns the body of the response of the given URL, the cached body is returned if the ETag matches
+func (u *URLIncluder) download(location *url.URL) ([]byte, error) {
+	if location.Scheme != "http" && location.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url scheme: %s", location)
+	}
+
+	if !u.isAllowed(location) {
+		return nil, fmt.Errorf("host is not allowed: %s", location)
+	}
+
+	ctx := context.Background()
+
+	if u.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, u.Timeout)
+
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, location.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, isCached := u.cached(location.String())
+	if isCached {
+		request.Header.Set("If-None-Match", cached.etag)
+	}
+
+	response, err := u.client().Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && isCached:
+		return cached.body, nil
+	case response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone:
+		return nil, fmt.Errorf("%s: %s: %w", location, response.Status, fs.ErrNotExist)
+	case response.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%s: unexpected status: %s", location, response.Status)
+	}
+
+	body, err := u.readBody(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", location, err)
+	}
+
+	if etag := response.Header.Get("ETag"); etag != "" {
+		u.store(location.String(), cachedResponse{etag: etag, body: body})
+	}
+
+	return body, nil
+}
+
+// readBody reads the body of the response up to the maximum size
+func (u *URLIncluder) readBody(body io.Reader) ([]byte, error) {
+	if u.MaxBodySize <= 0 {
+		return io.ReadAll(body)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(body, u.MaxBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(content)) > u.MaxBodySize {
+		return nil, fmt.Errorf("response body exceeds the maximum size of %d bytes", u.MaxBodySize)
+	}
+
+	return content, nil
+}
+
+// client returns the Client that does not follow the redirects to the hosts that are not allowed
+func (u *URLIncluder) client() *http.Client {
+	client := http.DefaultClient
+	if u.Client != nil {
+		client = u.Client
+	}
+
+	if len(u.AllowedHosts) == 0 {
+		return client
+	}
+
+	restricted := *client
+	restricted.CheckRedirect = func(request *http.Request, via []*http.Request) error {
+		if !u.isAllowed(request.URL) {
+			return fmt.Errorf("host is not allowed: %s", request.URL)
+		}
+
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(request, via)
+		}
+
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+
+		return nil
+	}
+
+	return &restricted
+}
+
+func (u *URLIncluder) isAllowed(location *url.URL) bool {
+	return len(u.AllowedHosts) == 0 || slices.Contains(u.AllowedHosts, location.Host)
+}
+
+func (u *URLIncluder) cached(location string) (cachedResponse, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	cached, ok := u.cache[location]
+
+	return cached, ok
+}
+
+func (u *URLIncluder) store(location string, response cachedResponse) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.cache == nil {
+		u.cache = make(map[string]cachedResponse)
+	}
+
+	u.cache[location] = response
+}
+
+// isHTTPURL reports whether the name of a source is an http or https URL
+func isHTTPURL(name string) bool {
+	return strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://")
+}
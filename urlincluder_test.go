@@ -0,0 +1,137 @@
+package hocon
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestURLIncluder(t *testing.T) {
+	var requests, notModified atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/conf/app.conf", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, "include \"db.conf\"\nname: app")
+	})
+	mux.HandleFunc("/conf/db.conf", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, "db.port: 5432")
+	})
+	mux.HandleFunc("/large.conf", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "a: "+strings.Repeat("x", 100))
+	})
+	mux.HandleFunc("/slow.conf", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	mux.HandleFunc("/error.conf", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("download the included url and the relative includes of it", func(t *testing.T) {
+		includer := &URLIncluder{Client: server.Client(), AllowedHosts: []string{host}}
+		input := fmt.Sprintf("include url(%q)\nlocal: 1", server.URL+"/conf/app.conf")
+		got, err := ParseStringWithOptions(input, ParseOptions{Includer: includer})
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"db":{"port":5432}, "name":"app", "local":1}`)
+		origin, err := got.Origin("db.port")
+		assertNoError(t, err)
+		assertEquals(t, origin.String(), fmt.Sprintf("%s/conf/db.conf:1:4 (included from %s/conf/app.conf <- string)", server.URL, server.URL))
+	})
+
+	t.Run("use the cached response if the etag matches", func(t *testing.T) {
+		requests.Store(0)
+		notModified.Store(0)
+		includer := &URLIncluder{}
+		input := fmt.Sprintf("include url(%q)", server.URL+"/conf/db.conf")
+
+		for i := 0; i < 2; i++ {
+			got, err := ParseStringWithOptions(input, ParseOptions{Includer: includer})
+			assertNoError(t, err)
+			assertEquals(t, got.GetIntOrPanic("db.port"), 5432)
+		}
+
+		assertEquals(t, requests.Load(), int32(2))
+		assertEquals(t, notModified.Load(), int32(1))
+	})
+
+	t.Run("open the other includes with the fallback", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include "testdata/a.conf"`, ParseOptions{Includer: &URLIncluder{}})
+		assertNoError(t, err)
+		assertEquals(t, got.GetIntOrPanic("a"), 1)
+	})
+
+	t.Run("ignore the optional url include that is not found", func(t *testing.T) {
+		input := fmt.Sprintf("include url(%q)\na: 1", server.URL+"/missing.conf")
+		got, err := ParseStringWithOptions(input, ParseOptions{Includer: &URLIncluder{}})
+		assertNoError(t, err)
+		assertEquals(t, got.String(), `{"a":1}`)
+	})
+
+	var errorTestCases = []struct {
+		name     string
+		includer *URLIncluder
+		path     string
+		expected string
+	}{
+		{"required url is not found", &URLIncluder{}, "/missing.conf", "%s/missing.conf: 404 Not Found: file does not exist"},
+		{"server returns an error", &URLIncluder{}, "/error.conf", "%s/error.conf: unexpected status: 500 Internal Server Error"},
+		{"host is not allowed", &URLIncluder{AllowedHosts: []string{"config.local"}}, "/conf/db.conf", "host is not allowed: %s/conf/db.conf"},
+		{"body exceeds the maximum size", &URLIncluder{MaxBodySize: 64}, "/large.conf", "%s/large.conf: response body exceeds the maximum size of 64 bytes"},
+	}
+
+	for _, tc := range errorTestCases {
+		t.Run("return an error if the "+tc.name, func(t *testing.T) {
+			input := fmt.Sprintf("include required(url(%q))", server.URL+tc.path)
+			got, err := ParseStringWithOptions(input, ParseOptions{Includer: tc.includer})
+			assertNil(t, got)
+			assertError(t, err, fmt.Errorf("could not parse resource: "+tc.expected, server.URL))
+		})
+	}
+
+	t.Run("return an error if the request times out", func(t *testing.T) {
+		input := fmt.Sprintf("include url(%q)", server.URL+"/slow.conf")
+		got, err := ParseStringWithOptions(input, ParseOptions{Includer: &URLIncluder{Timeout: 10 * time.Millisecond}})
+		assertNil(t, got)
+		var urlErr *url.Error
+		assertEquals(t, errors.As(err, &urlErr) && urlErr.Timeout(), true)
+	})
+
+	t.Run("return an error if the redirect leaves the allowed hosts", func(t *testing.T) {
+		redirect := httptest.NewServer(http.RedirectHandler(server.URL+"/conf/db.conf", http.StatusFound))
+		defer redirect.Close()
+
+		includer := &URLIncluder{AllowedHosts: []string{strings.TrimPrefix(redirect.URL, "http://")}}
+		input := fmt.Sprintf("include url(%q)", redirect.URL+"/db.conf")
+		got, err := ParseStringWithOptions(input, ParseOptions{Includer: includer})
+		assertNil(t, got)
+		expected := fmt.Sprintf(`could not parse resource: Get "%s/conf/db.conf": host is not allowed: %s/conf/db.conf`, server.URL, server.URL)
+		assertError(t, err, errors.New(expected))
+	})
+
+	t.Run("return an error for the unsupported schemes", func(t *testing.T) {
+		got, err := ParseStringWithOptions(`include url("ftp://localhost/a.conf")`, ParseOptions{Includer: &URLIncluder{}})
+		assertNil(t, got)
+		assertError(t, err, errors.New("could not parse resource: unsupported url scheme: ftp://localhost/a.conf"))
+	})
+}
@@ -0,0 +1,151 @@
+package hocon
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	requiredRule = "required"
+	minRule      = "min"
+	maxRule      = "max"
+	oneOfRule    = "oneof"
+)
+
+// Violation is a failed validation rule of a decoded field
+type Violation struct {
+	Path    string // the path of the config value
+	Message string // describes the failed rule, e.g. "is required"
+}
+
+// validate method checks the decoded field against the rules of its validate tag and records the violations,
+// returns an error only if the tag itself is invalid
+//
+// supported rules are:
+// required: the value must be present in the configuration (or have a default) and must not be null
+// min=N, max=N: bounds of the numbers and durations, or the length of the strings, slices and maps
+// oneof=a|b|c: the value must be one of the given options
+func (d *decoder) validate(f structField, path, field string, present bool, rv reflect.Value) error {
+	if f.rules == "" {
+		return nil
+	}
+
+	for _, rule := range strings.Split(f.rules, commaToken) {
+		name, argument, _ := strings.Cut(strings.TrimSpace(rule), equalsToken)
+
+		if name == requiredRule {
+			if !present {
+				d.violations = append(d.violations, Violation{Path: path, Message: "is required"})
+				return nil
+			}
+
+			continue
+		}
+
+		if !present {
+			continue
+		}
+
+		for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+
+		var message string
+		var err error
+
+		switch name {
+		case minRule:
+			message, err = checkBound(rv, argument, func(value, bound float64) bool { return value >= bound }, "at least")
+		case maxRule:
+			message, err = checkBound(rv, argument, func(value, bound float64) bool { return value <= bound }, "at most")
+		case oneOfRule:
+			message = checkOneOf(rv, strings.Split(argument, "|"))
+		default:
+			err = fmt.Errorf("unknown rule: %q", name)
+		}
+
+		if err != nil {
+			return unmarshalError(path, field, fmt.Sprintf("invalid validate tag: %q, %s", f.rules, err))
+		}
+
+		if message != "" {
+			d.violations = append(d.violations, Violation{Path: path, Message: message})
+		}
+	}
+
+	return nil
+}
+
+func (d *decoder) validationError() error {
+	if len(d.violations) == 0 {
+		return nil
+	}
+
+	return &ValidationError{violations: d.violations, errs: d.errs}
+}
+
+// checkBound compares the given value with the bound, durations are compared with the bound parsed as a
+// hocon duration, the strings, slices, arrays and maps are compared by their length
+func checkBound(rv reflect.Value, argument string, check func(value, bound float64) bool, description string) (string, error) {
+	if rv.Type() == durationType {
+		boundValue, err := parseValue(argument)
+		if err != nil {
+			return "", err
+		}
+
+		bound, ok := durationValue(boundValue)
+		if !ok {
+			return "", fmt.Errorf("%q is not a duration", argument)
+		}
+
+		if !check(float64(rv.Int()), float64(bound)) {
+			return fmt.Sprintf("must be %s %s", description, bound), nil
+		}
+
+		return "", nil
+	}
+
+	bound, err := strconv.ParseFloat(argument, 64)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a number", argument)
+	}
+
+	var value float64
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		value = rv.Float()
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		if !check(float64(rv.Len()), bound) {
+			return fmt.Sprintf("length must be %s %s", description, argument), nil
+		}
+
+		return "", nil
+	default:
+		return "", fmt.Errorf("cannot apply the bound to the type: %s", rv.Type())
+	}
+
+	if !check(value, bound) {
+		return fmt.Sprintf("must be %s %s", description, argument), nil
+	}
+
+	return "", nil
+}
+
+func checkOneOf(rv reflect.Value, options []string) string {
+	value := fmt.Sprint(rv.Interface())
+
+	for _, option := range options {
+		if value == option {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("must be one of [%s]", strings.Join(options, ", "))
+}